@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/config"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/targets"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/spf13/cobra"
+)
+
+// checkViolationExitCode is returned when rules are violated; other failures exit with 1.
+const checkViolationExitCode = 2
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check the portfolio against rules (for cron and schedulers)",
+	Long: "Evaluates rules against the current portfolio and exits with code 2 when any rule is violated " +
+		"(0 = all rules passed, 1 = the check could not run). Rules come from the 'check' section of config.yaml; flags override them.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		rules, err := checkRules(cmd)
+		if err != nil {
+			return err
+		}
+		if len(rules.Rules()) == 0 {
+			return fmt.Errorf("no rules configured; pass e.g. --max-position-pct 25 or add a 'check' section to config.yaml")
+		}
+
+		client, err := newTrading212Client()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		svc := portfolio.NewService(client)
+		output, err := svc.GetPortfolio(ctx, portfolio.PeriodRange{}, portfolio.PortfolioOptions{})
+		if err != nil {
+			return presentation.HumanizeAccountError(err)
+		}
+
+		result, err := portfolio.CheckPortfolio(output, rules, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			if err := enc.Encode(result); err != nil {
+				return err
+			}
+		} else if err := presentation.RenderCheckText(result, os.Stdout); err != nil {
+			return err
+		}
+
+		if !result.OK {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			return &exitCodeError{code: checkViolationExitCode}
+		}
+		return nil
+	},
+}
+
+// checkRules merges the config's check section with flags (flags win).
+func checkRules(cmd *cobra.Command) (portfolio.CheckRules, error) {
+	var rules portfolio.CheckRules
+	cc := &config.CheckConfig{}
+	if c := GetConfig(); c != nil && c.Check != nil {
+		cc = c.Check
+	}
+	rules.MaxDriftBps = cc.MaxDriftBps
+	rules.MaxPositionPct = cc.MaxPositionPct
+	rules.MaxFreeCash = cc.MaxFreeCash
+	rules.FailOnReconcile = cc.FailOnReconcile
+	targetsPath := cc.Targets
+
+	flags := cmd.Flags()
+	if flags.Changed("max-drift-bps") {
+		v, _ := flags.GetInt("max-drift-bps")
+		rules.MaxDriftBps = &v
+	}
+	if flags.Changed("max-position-pct") {
+		v, _ := flags.GetFloat64("max-position-pct")
+		rules.MaxPositionPct = &v
+	}
+	if flags.Changed("max-free-cash") {
+		v, _ := flags.GetFloat64("max-free-cash")
+		rules.MaxFreeCash = &v
+	}
+	if flags.Changed("fail-on-reconcile") {
+		rules.FailOnReconcile, _ = flags.GetBool("fail-on-reconcile")
+	}
+	if flags.Changed("targets") {
+		targetsPath, _ = flags.GetString("targets")
+	}
+
+	if rules.MaxDriftBps != nil {
+		if targetsPath == "" {
+			profile, err := GetProfile()
+			if err != nil {
+				return rules, err
+			}
+			if targetsPath, err = targets.DefaultPath(profile.Name); err != nil {
+				return rules, err
+			}
+		}
+		t, err := targets.Load(targetsPath)
+		if err != nil {
+			return rules, err
+		}
+		rules.Targets = &t
+	}
+	return rules, nil
+}
+
+// addCheckRuleFlags registers the rule flags read by checkRules.
+func addCheckRuleFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("max-drift-bps", 0, "Fail when a holding or group drifts more than N bps from its target (needs a targets file)")
+	cmd.Flags().Float64("max-position-pct", 0, "Fail when a single holding is above X% of holdings")
+	cmd.Flags().Float64("max-free-cash", 0, "Fail when free cash is above this amount (account currency)")
+	cmd.Flags().Bool("fail-on-reconcile", false, "Fail when the account does not reconcile")
+	cmd.Flags().String("targets", "", "Targets file for --max-drift-bps (default: ~/.folio212/targets.yaml)")
+}
+
+func init() {
+	checkCmd.Flags().Bool("json", false, "Output JSON ({ok, rules, violations[]})")
+	addCheckRuleFlags(checkCmd)
+}
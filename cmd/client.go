@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/config"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/secrets"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+)
+
+// newTrading212Client builds an API client for the selected profile.
+func newTrading212Client() (*trading212.Client, error) {
+	profile, err := GetProfile()
+	if err != nil {
+		return nil, err
+	}
+	return newTrading212ClientForProfile(profile)
+}
+
+// newTrading212ClientForProfile builds an API client from a profile and its stored API secret.
+func newTrading212ClientForProfile(profile config.Profile) (*trading212.Client, error) {
+	if strings.TrimSpace(profile.Trading212APIKey) == "" {
+		return nil, fmt.Errorf("%s", presentation.HumanizeDomainError(portfolio.ErrMissingAPIKey))
+	}
+
+	secret, _, err := secrets.Get(secrets.ProfileKey(secrets.KeyTrading212APISecret, profile.Name))
+	if err != nil {
+		return nil, err
+	}
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return nil, fmt.Errorf("%s", presentation.HumanizeDomainError(portfolio.ErrMissingAPISecret))
+	}
+
+	baseURL := trading212.BaseURLDemo
+	if strings.EqualFold(strings.TrimSpace(profile.Trading212Env), "live") {
+		baseURL = trading212.BaseURLLive
+	}
+
+	return trading212.NewClient(baseURL, profile.Trading212APIKey, secret)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/spf13/cobra"
+)
+
+var dashboardCmd = &cobra.Command{
+	Use:     "dashboard",
+	Aliases: []string{"tui"},
+	Short:   "Interactive portfolio dashboard",
+	Long: "Full-screen view of the portfolio: summary header, sortable holdings table and details for the selected holding.\n" +
+		"Keys: up/down (or j/k) select, s cycle sort, o reverse, r refresh, q quit. Refreshes are spaced to respect Trading212 rate limits.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		withTWR, _ := cmd.Flags().GetBool("twr")
+		withMWR, _ := cmd.Flags().GetBool("mwr")
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+
+		period, err := parsePeriod(fromStr, toStr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", presentation.HumanizeDomainError(portfolio.ErrInvalidPeriod), err)
+		}
+
+		client, err := newTrading212Client()
+		if err != nil {
+			return err
+		}
+
+		svc := newPortfolioService(client)
+		opts := portfolio.PortfolioOptions{WithFlows: withTWR, WithMWR: withMWR}
+		fetch := func(ctx context.Context) (*portfolio.Output, error) {
+			return svc.GetPortfolio(ctx, period, opts)
+		}
+		return runDashboard(fetch, portfolioTimeout(period, opts))
+	},
+}
+
+func runDashboard(fetch presentation.DashboardFetchFunc, timeout time.Duration) error {
+	finalModel, err := tea.NewProgram(presentation.NewDashboardModel(fetch, timeout)).Run()
+	if err != nil {
+		return err
+	}
+	if m, ok := finalModel.(*presentation.DashboardModel); ok && m.Error() != nil {
+		return presentation.HumanizeAccountError(m.Error())
+	}
+	return nil
+}
+
+func init() {
+	dashboardCmd.Flags().Bool("twr", false, "Compute flow-adjusted TWR on each refresh (requires History permission; with --from/--to, a snapshot at or before --from)")
+	dashboardCmd.Flags().Bool("mwr", false, "Compute money-weighted return on each refresh (requires History permission)")
+	dashboardCmd.Flags().String("from", "", "Reporting period start (YYYY-MM-DD)")
+	dashboardCmd.Flags().String("to", "", "Reporting period end (YYYY-MM-DD)")
+}
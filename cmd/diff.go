@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/snapshots"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/spf13/cobra"
+)
+
+const diffLiveLabel = "live"
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <from-id|latest> [to-id|latest|live]",
+	Short: "Compare two portfolio snapshots",
+	Long:  "Compares two stored snapshots, or a stored snapshot against live data (the default when the second argument is omitted).",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		top, _ := cmd.Flags().GetInt("top")
+		accountID, err := snapshotAccountID(cmd)
+		if err != nil {
+			return err
+		}
+
+		toID := diffLiveLabel
+		if len(args) == 2 {
+			toID = args[1]
+		}
+
+		store, err := snapshots.Open()
+		if err != nil {
+			return err
+		}
+
+		from, err := store.Get(args[0], accountID)
+		if err != nil {
+			return err
+		}
+		if from.Output == nil {
+			return fmt.Errorf("snapshot %s has no portfolio data", from.ID)
+		}
+
+		var to *portfolio.Output
+		toLabel := toID
+		if toID == diffLiveLabel {
+			client, err := newTrading212Client()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			svc := portfolio.NewService(client)
+			to, err = svc.GetPortfolio(ctx, portfolio.PeriodRange{}, portfolio.PortfolioOptions{})
+			if err != nil {
+				return presentation.HumanizeAccountError(err)
+			}
+		} else {
+			rec, err := store.Get(toID, accountID)
+			if err != nil {
+				return err
+			}
+			if rec.Output == nil {
+				return fmt.Errorf("snapshot %s has no portfolio data", rec.ID)
+			}
+			to = rec.Output
+			toLabel = rec.ID
+		}
+
+		d := portfolio.DiffOutputs(from.Output, to, from.ID, toLabel)
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			return enc.Encode(d)
+		}
+		return presentation.RenderDiffText(d, top, os.Stdout)
+	},
+}
+
+func init() {
+	diffCmd.Flags().Bool("json", false, "Output a structured delta document as JSON")
+	diffCmd.Flags().Int("top", 10, "Number of biggest movers to show in text output (0 = all)")
+	addSnapshotAccountFlags(diffCmd)
+}
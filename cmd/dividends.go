@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/spf13/cobra"
+)
+
+var dividendsCmd = &cobra.Command{
+	Use:   "dividends",
+	Short: "Show dividend income",
+	Long:  "Fetches paid-out dividends from Trading212 and groups them by ticker, month or year.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+		ticker, _ := cmd.Flags().GetString("ticker")
+		byStr, _ := cmd.Flags().GetString("by")
+
+		period, err := parsePeriod(fromStr, toStr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", presentation.HumanizeDomainError(portfolio.ErrInvalidPeriod), err)
+		}
+
+		groupBy, err := portfolio.ParseDividendGrouping(byStr)
+		if err != nil {
+			return fmt.Errorf("invalid --by: %w", err)
+		}
+
+		client, err := newTrading212Client()
+		if err != nil {
+			return err
+		}
+
+		// History endpoints are paginated and heavily rate limited; allow time for several pages.
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		svc := portfolio.NewService(client)
+		output, err := svc.GetDividends(ctx, period, strings.TrimSpace(ticker), groupBy)
+		if err != nil {
+			return presentation.HumanizeHistoryError(err)
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			return enc.Encode(output)
+		}
+
+		return presentation.RenderDividendsText(output, os.Stdout)
+	},
+}
+
+func init() {
+	dividendsCmd.Flags().Bool("json", false, "Output raw JSON")
+	dividendsCmd.Flags().String("from", "", "Period start (YYYY-MM-DD)")
+	dividendsCmd.Flags().String("to", "", "Period end (YYYY-MM-DD)")
+	dividendsCmd.Flags().String("ticker", "", "Only show dividends for this ticker (e.g. AAPL_US_EQ)")
+	dividendsCmd.Flags().String("by", string(portfolio.GroupByTicker), "Group by: ticker, month or year")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	formatText     = "text"
+	formatJSON     = "json"
+	formatCSV      = "csv"
+	formatMarkdown = "markdown"
+	formatHTML     = "html"
+	formatTable    = "table"
+)
+
+// outputFormat resolves the --format flag (with --json as a shortcut) against the formats a command supports.
+func outputFormat(cmd *cobra.Command, allowed ...string) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	format = strings.ToLower(strings.TrimSpace(format))
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		if format != "" && format != formatText && format != formatJSON {
+			return "", fmt.Errorf("--json conflicts with --format %s", format)
+		}
+		format = formatJSON
+	}
+	switch format {
+	case "":
+		format = formatText
+	case "md":
+		format = formatMarkdown
+	}
+	if !slices.Contains(allowed, format) {
+		return "", fmt.Errorf("unsupported --format %q (expected %s)", format, strings.Join(allowed, ", "))
+	}
+	return format, nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/snapshots"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/nezdemkovski/folio212/internal/shared/ui"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Chart portfolio value over time from stored snapshots",
+	Long:  "Plots account total, holdings value and cost basis (or selected metrics) from local snapshots. Prints a plain table when stdout is not a terminal.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+		metricStr, _ := cmd.Flags().GetString("metric")
+		ticker, _ := cmd.Flags().GetString("ticker")
+		accountID, err := snapshotAccountID(cmd)
+		if err != nil {
+			return err
+		}
+
+		period, err := parsePeriod(fromStr, toStr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", presentation.HumanizeDomainError(portfolio.ErrInvalidPeriod), err)
+		}
+		metrics, err := portfolio.ParseHistoryMetrics(metricStr)
+		if err != nil {
+			return fmt.Errorf("invalid --metric: %w", err)
+		}
+		if strings.TrimSpace(ticker) != "" && strings.TrimSpace(metricStr) == "" {
+			metrics = []portfolio.HistoryMetric{portfolio.MetricHoldingsValue, portfolio.MetricHoldingsCost}
+		}
+
+		store, err := snapshots.Open()
+		if err != nil {
+			return err
+		}
+		records, err := store.List(accountID)
+		if err != nil {
+			return err
+		}
+
+		points := make([]portfolio.SnapshotPoint, 0, len(records))
+		for _, r := range records {
+			points = append(points, portfolio.SnapshotPoint{At: r.SavedAt, Output: r.Output})
+		}
+
+		h, err := portfolio.BuildHistory(points, period, metrics, ticker)
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			return enc.Encode(h)
+		}
+		if !ui.IsTerminal(os.Stdout) {
+			return presentation.RenderHistoryTable(h, os.Stdout)
+		}
+		return presentation.RenderHistoryChart(h, terminalWidth()-4, os.Stdout)
+	},
+}
+
+// terminalWidth uses $COLUMNS when set and falls back to 80 columns.
+func terminalWidth() int {
+	return ui.TerminalWidth(os.Stdout, 80)
+}
+
+func init() {
+	historyCmd.Flags().Bool("json", false, "Output raw JSON series")
+	historyCmd.Flags().String("from", "", "Period start (YYYY-MM-DD)")
+	historyCmd.Flags().String("to", "", "Period end (YYYY-MM-DD)")
+	historyCmd.Flags().String("metric", "", "Comma-separated metrics: total, value, cost, pnl, cash, qty (default: total,value,cost)")
+	historyCmd.Flags().String("ticker", "", "Plot a single holding (metrics: value, cost, pnl, qty)")
+	addSnapshotAccountFlags(historyCmd)
+}
@@ -5,16 +5,24 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/nezdemkovski/folio212/internal/shared/validation"
 	"github.com/spf13/cobra"
 )
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize configuration",
-	Long:  "Interactive setup that writes a small config file to your home directory.",
+	Long: "Interactive setup that writes a small config file to your home directory.\n" +
+		"Use --profile NAME to add or update a named profile for another Trading212 account.",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if name := ProfileName(); name != "" {
+			if err := validation.ValidateProfileName(name); err != nil {
+				return err
+			}
+		}
+
 		p := tea.NewProgram(
-			presentation.NewInitModel(),
+			presentation.NewInitModel(ProfileName()),
 		)
 
 		finalModel, err := p.Run()
@@ -26,7 +34,7 @@ var initCmd = &cobra.Command{
 			if m.Error() != nil {
 				return m.Error()
 			}
-			fmt.Println(presentation.RenderInitCompletion(m.Config(), m.AccountSummary(), m.ValidationWarning(), m.SecretSource()))
+			fmt.Println(presentation.RenderInitCompletion(m.Profile(), m.AccountSummary(), m.ValidationWarning(), m.SecretSource()))
 		}
 
 		return nil
@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/mcp"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/snapshots"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/nezdemkovski/folio212/internal/shared/constants"
+	"github.com/spf13/cobra"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Run a Model Context Protocol server over stdio",
+	Long: "Serves portfolio, holding, order and dividend tools plus reference resources to MCP clients " +
+		"(e.g. Claude Desktop) over stdin/stdout. Tools are read-only unless --allow-write is set.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		allowWrite, _ := cmd.Flags().GetBool("allow-write")
+
+		client, err := newTrading212Client()
+		if err != nil {
+			return err
+		}
+		tools := mcpTools(newPortfolioService(client))
+		if allowWrite {
+			tools = append(tools, mcpWriteTools(client)...)
+		}
+
+		srv := mcp.NewServer(constants.AppName, buildVersion(),
+			mcp.WithInstructions("Read-only access to the user's Trading212 account. Call get_portfolio for the "+
+				"account summary and holdings; amounts are in the account currency. Read folio212://skill for field meanings."),
+			mcp.WithTools(tools...),
+			mcp.WithResources(mcpResources()...),
+		)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		// stdout carries the protocol; anything else goes to stderr.
+		return srv.Serve(ctx, os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	mcpCmd.Flags().Bool("allow-write", false, "Also expose tools that change local state (save_snapshot)")
+}
+
+// buildVersion is the module version for installed binaries, "dev" otherwise.
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
+// Shared input schema fragments.
+const (
+	mcpDateSchema   = `{"type": "string", "pattern": "^\\d{4}-\\d{2}-\\d{2}$", "description": "YYYY-MM-DD; from and to must be given together"}`
+	mcpTickerSchema = `{"type": "string", "minLength": 1, "maxLength": 32, "pattern": "^[A-Za-z0-9._-]+$", "description": "Trading212 ticker, e.g. AAPL_US_EQ"}`
+)
+
+var (
+	mcpTickerPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,32}$`)
+	mcpISINPattern   = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{9}[0-9]$`)
+)
+
+// mcpMaxOrders caps list_orders so a result stays small enough for a model's context.
+const mcpMaxOrders = 500
+
+func mcpTools(svc *portfolio.Service) []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:  "get_portfolio",
+			Title: "Portfolio report",
+			Description: "Account summary, allocation and holdings (same JSON as 'folio212 portfolio --json'). " +
+				"Optionally for a period, with flow-adjusted TWR or MWR, and with holdings sorted, filtered, truncated or projected.",
+			ReadOnly: true,
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "from": ` + mcpDateSchema + `,
+    "to": ` + mcpDateSchema + `,
+    "twr": {"type": "boolean", "description": "Flow-adjusted TWR from deposit/withdrawal history (slow; History permission)"},
+    "mwr": {"type": "boolean", "description": "Money-weighted returns for the account and each holding (slow; History permission)"},
+    "sort": {"type": "string", "enum": ["value", "pnl", "return", "ticker", "opened"]},
+    "reverse": {"type": "boolean"},
+    "filter": {"type": "array", "items": {"type": "string"}, "description": "FIELD OP VALUE expressions, all must match, e.g. [\"currency=USD\", \"pnl<0\"]"},
+    "top": {"type": "integer", "minimum": 0, "description": "Keep only the first N holdings (0 = all)"},
+    "fields": {"type": "array", "items": {"type": "string"}, "description": "Holding fields to return, e.g. [\"ticker\", \"value\", \"pnl\"]"}
+  },
+  "additionalProperties": false
+}`),
+			Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args struct {
+					From    string   `json:"from"`
+					To      string   `json:"to"`
+					TWR     bool     `json:"twr"`
+					MWR     bool     `json:"mwr"`
+					Sort    string   `json:"sort"`
+					Reverse bool     `json:"reverse"`
+					Filter  []string `json:"filter"`
+					Top     int      `json:"top"`
+					Fields  []string `json:"fields"`
+				}
+				if err := mcp.DecodeArguments(raw, &args); err != nil {
+					return nil, err
+				}
+				period, err := mcpPeriod(args.From, args.To)
+				if err != nil {
+					return nil, err
+				}
+				sel := portfolio.HoldingSelection{Reverse: args.Reverse, Top: args.Top}
+				if sel.Sort, err = portfolio.ParseHoldingSortKey(args.Sort); err != nil {
+					return nil, fmt.Errorf("%w: sort: %s", mcp.ErrInvalidArguments, err)
+				}
+				if sel.Top < 0 {
+					return nil, fmt.Errorf("%w: top must be >= 0", mcp.ErrInvalidArguments)
+				}
+				for _, expr := range args.Filter {
+					f, err := portfolio.ParseHoldingFilter(expr)
+					if err != nil {
+						return nil, fmt.Errorf("%w: filter: %s", mcp.ErrInvalidArguments, err)
+					}
+					sel.Filters = append(sel.Filters, f)
+				}
+				fields, err := presentation.ParseHoldingFields(args.Fields)
+				if err != nil {
+					return nil, fmt.Errorf("%w: fields: %s", mcp.ErrInvalidArguments, err)
+				}
+
+				opts := portfolio.PortfolioOptions{WithFlows: args.TWR, WithMWR: args.MWR}
+				ctx, cancel := context.WithTimeout(ctx, portfolioTimeout(period, opts))
+				defer cancel()
+				output, err := svc.GetPortfolio(ctx, period, opts)
+				if err != nil {
+					return nil, presentation.HumanizeAccountError(err)
+				}
+				sel.Apply(output)
+
+				var buf bytes.Buffer
+				if err := presentation.RenderPortfolioJSON(output, fields, &buf); err != nil {
+					return nil, err
+				}
+				return json.RawMessage(bytes.TrimSpace(buf.Bytes())), nil
+			},
+		},
+		{
+			Name:        "get_holding",
+			Title:       "Single holding",
+			Description: "One open position by ticker or ISIN (exactly one of them), with market value, cost, uPnL, FX impact and weight in the account currency.",
+			ReadOnly:    true,
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "ticker": ` + mcpTickerSchema + `,
+    "isin": {"type": "string", "pattern": "^[A-Z]{2}[A-Z0-9]{9}[0-9]$", "description": "ISIN, e.g. US0378331005"}
+  },
+  "additionalProperties": false
+}`),
+			Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args struct {
+					Ticker string `json:"ticker"`
+					ISIN   string `json:"isin"`
+				}
+				if err := mcp.DecodeArguments(raw, &args); err != nil {
+					return nil, err
+				}
+				if (args.Ticker == "") == (args.ISIN == "") {
+					return nil, fmt.Errorf("%w: pass exactly one of ticker or isin", mcp.ErrInvalidArguments)
+				}
+				if args.Ticker != "" {
+					if err := mcpValidateTicker(args.Ticker); err != nil {
+						return nil, err
+					}
+				} else if !mcpISINPattern.MatchString(args.ISIN) {
+					return nil, fmt.Errorf("%w: isin must be 12 characters, e.g. US0378331005", mcp.ErrInvalidArguments)
+				}
+
+				ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+				defer cancel()
+				output, err := svc.GetPortfolio(ctx, portfolio.PeriodRange{}, portfolio.PortfolioOptions{})
+				if err != nil {
+					return nil, presentation.HumanizeAccountError(err)
+				}
+				for _, h := range output.Holdings {
+					if (args.Ticker != "" && strings.EqualFold(h.Ticker, args.Ticker)) || (args.ISIN != "" && h.ISIN == args.ISIN) {
+						return h, nil
+					}
+				}
+				if args.Ticker != "" {
+					return nil, fmt.Errorf("no open position for ticker %s", args.Ticker)
+				}
+				return nil, fmt.Errorf("no open position for ISIN %s", args.ISIN)
+			},
+		},
+		{
+			Name:        "list_orders",
+			Title:       "Executed orders",
+			Description: "Filled buy and sell orders, newest first, with totals (same JSON as 'folio212 orders --json'). Requires the History permission; paging history is slow.",
+			ReadOnly:    true,
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "from": ` + mcpDateSchema + `,
+    "to": ` + mcpDateSchema + `,
+    "ticker": ` + mcpTickerSchema + `,
+    "limit": {"type": "integer", "minimum": 1, "maximum": ` + fmt.Sprint(mcpMaxOrders) + `, "description": "Return at most N orders (totals still cover all of them); default ` + fmt.Sprint(mcpMaxOrders) + `"}
+  },
+  "additionalProperties": false
+}`),
+			Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args struct {
+					From   string `json:"from"`
+					To     string `json:"to"`
+					Ticker string `json:"ticker"`
+					Limit  *int   `json:"limit"`
+				}
+				if err := mcp.DecodeArguments(raw, &args); err != nil {
+					return nil, err
+				}
+				period, err := mcpPeriod(args.From, args.To)
+				if err != nil {
+					return nil, err
+				}
+				if args.Ticker != "" {
+					if err := mcpValidateTicker(args.Ticker); err != nil {
+						return nil, err
+					}
+				}
+				limit := mcpMaxOrders
+				if args.Limit != nil {
+					if *args.Limit < 1 || *args.Limit > mcpMaxOrders {
+						return nil, fmt.Errorf("%w: limit must be between 1 and %d", mcp.ErrInvalidArguments, mcpMaxOrders)
+					}
+					limit = *args.Limit
+				}
+
+				ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+				defer cancel()
+				output, err := svc.GetOrders(ctx, period, args.Ticker)
+				if err != nil {
+					return nil, presentation.HumanizeHistoryError(err)
+				}
+				if len(output.Orders) > limit {
+					output.Orders = output.Orders[:limit]
+				}
+				return output, nil
+			},
+		},
+		{
+			Name:        "get_dividends",
+			Title:       "Dividend income",
+			Description: "Paid-out dividends grouped by ticker, month or year, with totals (same JSON as 'folio212 dividends --json'). Requires the History permission.",
+			ReadOnly:    true,
+			InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "from": ` + mcpDateSchema + `,
+    "to": ` + mcpDateSchema + `,
+    "ticker": ` + mcpTickerSchema + `,
+    "by": {"type": "string", "enum": ["ticker", "month", "year"], "description": "Grouping (default ticker)"}
+  },
+  "additionalProperties": false
+}`),
+			Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args struct {
+					From   string `json:"from"`
+					To     string `json:"to"`
+					Ticker string `json:"ticker"`
+					By     string `json:"by"`
+				}
+				if err := mcp.DecodeArguments(raw, &args); err != nil {
+					return nil, err
+				}
+				period, err := mcpPeriod(args.From, args.To)
+				if err != nil {
+					return nil, err
+				}
+				if args.Ticker != "" {
+					if err := mcpValidateTicker(args.Ticker); err != nil {
+						return nil, err
+					}
+				}
+				if args.By == "" {
+					args.By = string(portfolio.GroupByTicker)
+				}
+				groupBy, err := portfolio.ParseDividendGrouping(args.By)
+				if err != nil {
+					return nil, fmt.Errorf("%w: by: %s", mcp.ErrInvalidArguments, err)
+				}
+
+				ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+				defer cancel()
+				output, err := svc.GetDividends(ctx, period, args.Ticker, groupBy)
+				if err != nil {
+					return nil, presentation.HumanizeHistoryError(err)
+				}
+				return output, nil
+			},
+		},
+	}
+}
+
+// mcpWriteTools are only registered with --allow-write.
+func mcpWriteTools(client *trading212.Client) []mcp.Tool {
+	return []mcp.Tool{{
+		Name:        "save_snapshot",
+		Title:       "Save snapshot",
+		Description: "Fetch the current portfolio and append it to the local snapshot log (same as 'folio212 snapshot save'). Does not place orders.",
+		InputSchema: json.RawMessage(`{"type": "object", "properties": {}, "additionalProperties": false}`),
+		Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var args struct{}
+			if err := mcp.DecodeArguments(raw, &args); err != nil {
+				return nil, err
+			}
+			store, err := snapshots.Open()
+			if err != nil {
+				return nil, err
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+			defer cancel()
+			svc := portfolio.NewService(client)
+			output, err := svc.GetPortfolio(ctx, portfolio.PeriodRange{}, portfolio.PortfolioOptions{})
+			if err != nil {
+				return nil, presentation.HumanizeAccountError(err)
+			}
+			rec, err := store.Save(output, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			return presentation.NewSnapshotSummary(*rec), nil
+		},
+	}}
+}
+
+func mcpResources() []mcp.Resource {
+	return []mcp.Resource{
+		{
+			URI:         "folio212://skill",
+			Name:        "folio212 reference",
+			Description: "Commands, JSON output fields and metric definitions.",
+			MimeType:    "text/markdown",
+			Read:        func() (string, error) { return skillText, nil },
+		},
+		{
+			URI:         "folio212://schema/portfolio",
+			Name:        "Portfolio JSON Schema",
+			Description: "JSON Schema of the get_portfolio result (same as 'folio212 schema').",
+			MimeType:    "application/schema+json",
+			Read: func() (string, error) {
+				s, err := portfolio.OutputSchema(portfolio.SchemaVersion)
+				if err != nil {
+					return "", err
+				}
+				b, err := json.MarshalIndent(s, "", "  ")
+				return string(b), err
+			},
+		},
+	}
+}
+
+// mcpPeriod validates an optional from/to pair as parsePeriod does for the CLI flags.
+func mcpPeriod(from, to string) (portfolio.PeriodRange, error) {
+	if (from == "") != (to == "") {
+		return portfolio.PeriodRange{}, fmt.Errorf("%w: from and to must be given together (YYYY-MM-DD)", mcp.ErrInvalidArguments)
+	}
+	period, err := parsePeriod(from, to)
+	if err != nil {
+		return period, fmt.Errorf("%w: %s", mcp.ErrInvalidArguments, strings.ReplaceAll(err.Error(), "--", ""))
+	}
+	return period, nil
+}
+
+func mcpValidateTicker(ticker string) error {
+	if !mcpTickerPattern.MatchString(ticker) {
+		return fmt.Errorf("%w: ticker must be 1-32 letters, digits, '.', '_' or '-' (e.g. AAPL_US_EQ)", mcp.ErrInvalidArguments)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/mcp"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+	"github.com/nezdemkovski/folio212/internal/shared/jsonschema"
+)
+
+// fakeTrading212 serves a one-holding account.
+func fakeTrading212(t *testing.T) *trading212.Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v0/equity/account/summary", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id": 42, "currency": "EUR", "totalValue": 1100,
+			"cash": {"availableToTrade": 100, "inPies": 0, "reservedForOrders": 0},
+			"investments": {"currentValue": 1000, "totalCost": 800, "realizedProfitLoss": 0, "unrealizedProfitLoss": 200}}`)
+	})
+	mux.HandleFunc("GET /api/v0/equity/positions", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[{"instrument": {"ticker": "AAPL_US_EQ", "name": "Apple", "isin": "US0378331005", "currency": "USD"},
+			"quantity": 5, "quantityAvailableForTrading": 5, "averagePricePaid": 150, "currentPrice": 200,
+			"createdAt": "2024-01-02T10:00:00Z",
+			"walletImpact": {"currency": "EUR", "totalCost": 800, "currentValue": 1000, "unrealizedProfitLoss": 200, "fxImpact": 10}}]`)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client, err := trading212.NewClient(srv.URL, "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+type rpcReply struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// TestMCPSession drives the server as a client would: one request per line on stdin, one reply
+// per line on stdout.
+func TestMCPSession(t *testing.T) {
+	srv := mcp.NewServer("folio212", "test",
+		mcp.WithTools(mcpTools(portfolio.NewService(fakeTrading212(t)))...),
+		mcp.WithResources(mcpResources()...),
+	)
+	script := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"get_holding","arguments":{"ticker":"AAPL_US_EQ","isin":"US0378331005"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"list_orders","arguments":{"limit":0}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"get_portfolio","arguments":{"unknown":true}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"get_dividends","arguments":{"from":"2025-01-01"}}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"get_holding","arguments":{"isin":"US0378331005"}}}`,
+		`{"jsonrpc":"2.0","id":8,"method":"resources/read","params":{"uri":"folio212://skill"}}`,
+		`{"jsonrpc":"2.0","id":9,"method":"resources/read","params":{"uri":"folio212://nope"}}`,
+		`{"jsonrpc":"2.0","id":10,"method":"resources/read","params":{"uri":"folio212://schema/portfolio"}}`,
+	}, "\n") + "\n"
+
+	var out strings.Builder
+	if err := srv.Serve(context.Background(), strings.NewReader(script), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	replies := map[int]rpcReply{}
+	sc := bufio.NewScanner(strings.NewReader(out.String()))
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		var r rpcReply
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("reply is not one JSON object per line: %v: %s", err, sc.Text())
+		}
+		replies[r.ID] = r
+	}
+	if len(replies) != 10 {
+		t.Fatalf("got %d replies, want 10 (the notification gets none):\n%s", len(replies), out.String())
+	}
+
+	var initResult struct {
+		ProtocolVersion string                     `json:"protocolVersion"`
+		Capabilities    map[string]json.RawMessage `json:"capabilities"`
+	}
+	mustResult(t, replies[1], &initResult)
+	if initResult.ProtocolVersion != "2025-06-18" || initResult.Capabilities["tools"] == nil || initResult.Capabilities["resources"] == nil {
+		t.Errorf("initialize = %+v", initResult)
+	}
+
+	var list struct {
+		Tools []struct {
+			Name        string `json:"name"`
+			Annotations struct {
+				ReadOnlyHint bool `json:"readOnlyHint"`
+			} `json:"annotations"`
+		} `json:"tools"`
+	}
+	mustResult(t, replies[2], &list)
+	var names []string
+	for _, tool := range list.Tools {
+		names = append(names, tool.Name)
+		if !tool.Annotations.ReadOnlyHint {
+			t.Errorf("%s is not read-only", tool.Name)
+		}
+	}
+	if got := strings.Join(names, ","); got != "get_portfolio,get_holding,list_orders,get_dividends" {
+		t.Errorf("tools = %s (save_snapshot needs --allow-write)", got)
+	}
+
+	for id, want := range map[int]string{
+		3: "exactly one of ticker or isin",
+		4: "limit must be between 1 and 500",
+		5: `unknown field "unknown"`,
+		6: "from and to must be given together",
+	} {
+		r := replies[id]
+		if r.Error == nil || r.Error.Code != -32602 || !strings.Contains(r.Error.Message, want) {
+			t.Errorf("reply %d = %s / %+v, want invalid params containing %q", id, r.Result, r.Error, want)
+		}
+	}
+
+	var call struct {
+		IsError           bool `json:"isError"`
+		StructuredContent struct {
+			Ticker      string  `json:"ticker"`
+			MarketValue float64 `json:"marketValue"`
+		} `json:"structuredContent"`
+	}
+	mustResult(t, replies[7], &call)
+	if call.IsError || call.StructuredContent.Ticker != "AAPL_US_EQ" || call.StructuredContent.MarketValue != 1000 {
+		t.Errorf("get_holding = %s", replies[7].Result)
+	}
+
+	var read struct {
+		Contents []struct {
+			URI      string `json:"uri"`
+			MimeType string `json:"mimeType"`
+			Text     string `json:"text"`
+		} `json:"contents"`
+	}
+	mustResult(t, replies[8], &read)
+	if len(read.Contents) != 1 || read.Contents[0].MimeType != "text/markdown" || read.Contents[0].Text == "" {
+		t.Errorf("resources/read = %s", replies[8].Result)
+	}
+	if r := replies[9]; r.Error == nil || r.Error.Code != -32002 {
+		t.Errorf("unknown resource = %+v", r)
+	}
+
+	mustResult(t, replies[10], &read)
+	if len(read.Contents) != 1 || read.Contents[0].MimeType != "application/schema+json" {
+		t.Fatalf("schema resources/read = %s", replies[10].Result)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &schema); err != nil || schema["$schema"] != jsonschema.Draft {
+		t.Errorf("schema resource is not a JSON Schema document: %v", err)
+	}
+}
+
+func mustResult(t *testing.T, r rpcReply, v any) {
+	t.Helper()
+	if r.Error != nil {
+		t.Fatalf("reply %d: error %d %s", r.ID, r.Error.Code, r.Error.Message)
+	}
+	if err := json.Unmarshal(r.Result, v); err != nil {
+		t.Fatalf("reply %d: %v: %s", r.ID, err, r.Result)
+	}
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/notify"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/snapshots"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/spf13/cobra"
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Send a portfolio notification to the configured webhooks",
+	Long: "Builds a notification (summary, daily change since the last snapshot from a previous day, and rule violations) " +
+		"and posts it to the webhooks in the 'notify' section of config.yaml. Rules are the same as for 'folio212 check'.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		names, _ := cmd.Flags().GetStringSlice("webhook")
+		onlyViolations, _ := cmd.Flags().GetBool("only-violations")
+
+		webhooks, sender, err := notifyWebhooks(names, dryRun)
+		if err != nil {
+			return err
+		}
+		if len(webhooks) == 0 && !dryRun {
+			return fmt.Errorf("no webhooks configured; add a 'notify' section to config.yaml or use --dry-run to preview the payload")
+		}
+
+		rules, err := checkRules(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := newTrading212Client()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		svc := portfolio.NewService(client)
+		output, err := svc.GetPortfolio(ctx, portfolio.PeriodRange{}, portfolio.PortfolioOptions{})
+		if err != nil {
+			return presentation.HumanizeAccountError(err)
+		}
+
+		now := time.Now()
+		var check *portfolio.CheckOutput
+		if len(rules.Rules()) > 0 {
+			if check, err = portfolio.CheckPortfolio(output, rules, now); err != nil {
+				return err
+			}
+		}
+
+		store, err := snapshots.Open()
+		if err != nil {
+			return err
+		}
+		records, err := store.List(output.Summary.AccountID)
+		if err != nil {
+			return err
+		}
+		points := make([]portfolio.SnapshotPoint, 0, len(records))
+		for _, r := range records {
+			points = append(points, portfolio.SnapshotPoint{At: r.SavedAt, Output: r.Output})
+		}
+
+		n := portfolio.NewNotification(output, portfolio.DailyBaseline(points, now), check, now)
+		if profile, err := GetProfile(); err == nil {
+			n.Profile = profile.Name
+		}
+
+		if onlyViolations && n.Event != portfolio.EventViolations {
+			fmt.Fprintln(os.Stderr, "No rule violations; nothing sent.")
+			return nil
+		}
+
+		if dryRun {
+			return printNotifyDryRun(n, webhooks, sender)
+		}
+
+		sendCtx, sendCancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer sendCancel()
+
+		var errs []error
+		sent := 0
+		for _, w := range webhooks {
+			if !w.Wants(n.Event) {
+				continue
+			}
+			body, err := w.Render(n)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			attempts, err := sender.Send(sendCtx, w, body)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%w (after %d attempt(s))", err, attempts))
+				continue
+			}
+			sent++
+			fmt.Fprintf(os.Stdout, "Sent %s notification to %s\n", n.Event, w.Name)
+		}
+		if sent == 0 && len(errs) == 0 {
+			fmt.Fprintf(os.Stderr, "No webhook subscribes to %q events; nothing sent.\n", n.Event)
+		}
+		return errors.Join(errs...)
+	},
+}
+
+// notifyWebhooks resolves the configured webhooks, optionally limited to names. With dryRun the
+// sender prints requests to stdout instead of sending them.
+func notifyWebhooks(names []string, dryRun bool) ([]*notify.Webhook, *notify.Sender, error) {
+	retries := notify.DefaultRetries
+	timeout := notify.DefaultTimeout
+	var configured []*notify.Webhook
+
+	if c := GetConfig(); c != nil && c.Notify != nil {
+		nc := c.Notify
+		if nc.Retries != nil {
+			retries = *nc.Retries
+		}
+		if strings.TrimSpace(nc.Timeout) != "" {
+			d, err := time.ParseDuration(nc.Timeout)
+			if err != nil || d <= 0 {
+				return nil, nil, fmt.Errorf("invalid notify.timeout %q (expected a duration such as 10s)", nc.Timeout)
+			}
+			timeout = d
+		}
+		for _, wc := range nc.Webhooks {
+			if len(names) > 0 && !slices.Contains(names, wc.Name) {
+				continue
+			}
+			w, err := notify.NewWebhook(wc)
+			if err != nil {
+				return nil, nil, err
+			}
+			configured = append(configured, w)
+		}
+	}
+
+	for _, name := range names {
+		if !slices.ContainsFunc(configured, func(w *notify.Webhook) bool { return w.Name == name }) {
+			return nil, nil, fmt.Errorf("webhook %q is not configured in config.yaml", name)
+		}
+	}
+
+	opts := []notify.Option{
+		notify.WithRetries(retries),
+		notify.WithHTTPClient(&http.Client{Timeout: timeout}),
+	}
+	if dryRun {
+		opts = append(opts, notify.WithDryRun(os.Stdout))
+	}
+	return configured, notify.NewSender(opts...), nil
+}
+
+// printNotifyDryRun prints what would be sent: the JSON payload, or each webhook's rendered body
+// through a dry-run sender.
+func printNotifyDryRun(n *portfolio.Notification, webhooks []*notify.Webhook, sender *notify.Sender) error {
+	if len(webhooks) == 0 {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(n)
+	}
+	for i, w := range webhooks {
+		if i > 0 {
+			fmt.Fprintln(os.Stdout)
+		}
+		if !w.Wants(n.Event) {
+			fmt.Fprintf(os.Stdout, "# %s: skipped (not subscribed to %q events)\n", w.Name, n.Event)
+			continue
+		}
+		body, err := w.Render(n)
+		if err != nil {
+			return err
+		}
+		if _, err := sender.Send(context.Background(), w, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	notifyCmd.Flags().Bool("dry-run", false, "Print the payload instead of sending it")
+	notifyCmd.Flags().StringSlice("webhook", nil, "Only send to these webhooks (by name; repeatable)")
+	notifyCmd.Flags().Bool("only-violations", false, "Send only when a rule is violated (for frequent cron runs)")
+	addCheckRuleFlags(notifyCmd)
+}
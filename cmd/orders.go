@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/spf13/cobra"
+)
+
+var ordersCmd = &cobra.Command{
+	Use:   "orders",
+	Short: "Show executed orders",
+	Long:  "Fetches historical orders from Trading212 and prints executed buys and sells for a period.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+		ticker, _ := cmd.Flags().GetString("ticker")
+
+		period, err := parsePeriod(fromStr, toStr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", presentation.HumanizeDomainError(portfolio.ErrInvalidPeriod), err)
+		}
+
+		client, err := newTrading212Client()
+		if err != nil {
+			return err
+		}
+
+		// History endpoints are paginated and heavily rate limited; allow time for several pages.
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		svc := portfolio.NewService(client)
+		output, err := svc.GetOrders(ctx, period, strings.TrimSpace(ticker))
+		if err != nil {
+			return presentation.HumanizeHistoryError(err)
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			return enc.Encode(output)
+		}
+
+		return presentation.RenderOrdersText(output, os.Stdout)
+	},
+}
+
+func init() {
+	ordersCmd.Flags().Bool("json", false, "Output raw JSON")
+	ordersCmd.Flags().String("from", "", "Period start (YYYY-MM-DD)")
+	ordersCmd.Flags().String("to", "", "Period end (YYYY-MM-DD)")
+	ordersCmd.Flags().String("ticker", "", "Only show orders for this ticker (e.g. AAPL_US_EQ)")
+}
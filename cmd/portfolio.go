@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
-	"github.com/nezdemkovski/folio212/internal/infrastructure/secrets"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/snapshots"
 	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
 	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/nezdemkovski/folio212/internal/shared/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -21,31 +24,41 @@ var portfolioCmd = &cobra.Command{
 	Short:   "Show current holdings",
 	Long:    "Fetches open positions from Trading212 and prints holdings.",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		asJSON, _ := cmd.Flags().GetBool("json")
+		asTUI, _ := cmd.Flags().GetBool("tui")
 		includeRaw, _ := cmd.Flags().GetBool("include-raw")
+		withTWR, _ := cmd.Flags().GetBool("twr")
+		withMWR, _ := cmd.Flags().GetBool("mwr")
 		fromStr, _ := cmd.Flags().GetString("from")
 		toStr, _ := cmd.Flags().GetString("to")
+		allProfiles, _ := cmd.Flags().GetBool("all-profiles")
+		currency, _ := cmd.Flags().GetString("currency")
+		fxSpecs, _ := cmd.Flags().GetStringSlice("fx")
 
-		cfg := GetConfig()
-		if cfg == nil {
-			return fmt.Errorf("%s", presentation.HumanizeDomainError(portfolio.ErrConfigNotLoaded))
+		format, err := outputFormat(cmd, formatText, formatJSON, formatCSV, formatMarkdown, formatHTML, formatTable)
+		if err != nil {
+			return err
 		}
-		if strings.TrimSpace(cfg.Trading212APIKey) == "" {
-			return fmt.Errorf("%s", presentation.HumanizeDomainError(portfolio.ErrMissingAPIKey))
+		selection, fields, err := portfolioSelection(cmd, format)
+		if err != nil {
+			return err
 		}
-
-		secret, _, err := secrets.Get(secrets.KeyTrading212APISecret)
+		csvOpts, err := portfolioCSVOptions(cmd, format, fields)
 		if err != nil {
 			return err
 		}
-		secret = strings.TrimSpace(secret)
-		if secret == "" {
-			return fmt.Errorf("%s", presentation.HumanizeDomainError(portfolio.ErrMissingAPISecret))
+		schemaVersion, err := portfolioSchemaVersion(cmd, format, fields)
+		if err != nil {
+			return err
 		}
 
-		baseURL := trading212.BaseURLDemo
-		if strings.EqualFold(strings.TrimSpace(cfg.Trading212Env), "live") {
-			baseURL = trading212.BaseURLLive
+		var tmpl *template.Template
+		if spec, _ := cmd.Flags().GetString("template"); spec != "" {
+			if format != formatText {
+				return fmt.Errorf("--template conflicts with --format %s", format)
+			}
+			if tmpl, err = presentation.ParseOutputTemplate(spec); err != nil {
+				return err
+			}
 		}
 
 		period, err := parsePeriod(fromStr, toStr)
@@ -53,29 +66,199 @@ var portfolioCmd = &cobra.Command{
 			return fmt.Errorf("%s: %w", presentation.HumanizeDomainError(portfolio.ErrInvalidPeriod), err)
 		}
 
-		client, err := trading212.NewClient(baseURL, cfg.Trading212APIKey, secret)
-		if err != nil {
-			return err
+		opts := portfolio.PortfolioOptions{
+			IncludeRaw: includeRaw,
+			WithFlows:  withTWR,
+			WithMWR:    withMWR,
+		}
+		timeout := portfolioTimeout(period, opts)
+
+		var fetch presentation.DashboardFetchFunc
+		if allProfiles {
+			fxRates, err := portfolio.ParseFXRates(fxSpecs)
+			if err != nil {
+				return err
+			}
+			copts := consolidatedOptions{currency: currency, fxRates: fxRates}
+			fetch = func(ctx context.Context) (*portfolio.Output, error) {
+				return fetchAllProfiles(ctx, period, opts, copts)
+			}
+			timeout *= time.Duration(max(len(GetConfig().ProfileNames()), 1))
+		} else {
+			client, err := newTrading212Client()
+			if err != nil {
+				return err
+			}
+			svc := newPortfolioService(client)
+			fetch = func(ctx context.Context) (*portfolio.Output, error) {
+				return svc.GetPortfolio(ctx, period, opts)
+			}
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		if asTUI {
+			return runDashboard(fetch, timeout)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
-		svc := portfolio.NewService(client)
-		output, err := svc.GetPortfolio(ctx, period, includeRaw)
+		output, err := fetch(ctx)
 		if err != nil {
 			return presentation.HumanizeAccountError(err)
 		}
 
-		if asJSON {
-			enc := json.NewEncoder(os.Stdout)
-			return enc.Encode(output)
+		csvOpts.HoldingsCount = len(output.Holdings)
+		selection.Apply(output)
+
+		if tmpl != nil {
+			return presentation.RenderPortfolioTemplate(output, tmpl, os.Stdout)
+		}
+
+		switch format {
+		case formatJSON:
+			if schemaVersion != 0 && schemaVersion != portfolio.SchemaVersion {
+				return renderVersionedJSON(output, schemaVersion, os.Stdout, os.Stderr)
+			}
+			return presentation.RenderPortfolioJSON(output, fields, os.Stdout)
+		case formatCSV:
+			return presentation.RenderPortfolioCSV(output, csvOpts, os.Stdout)
+		case formatMarkdown:
+			return presentation.RenderPortfolioMarkdown(output, os.Stdout)
+		case formatHTML:
+			return presentation.RenderPortfolioHTML(output, os.Stdout)
+		case formatTable:
+			return presentation.RenderPortfolioTable(output, presentation.TableOptions{
+				Width: ui.TerminalWidth(os.Stdout, 0),
+				Color: ui.ColorEnabled(os.Stdout),
+			}, os.Stdout)
 		}
 
-		return presentation.RenderPortfolioText(output, os.Stdout)
+		return presentation.RenderPortfolioTextFields(output, fields, os.Stdout)
 	},
 }
 
+// portfolioSelection reads --sort, --reverse, --filter, --top and --fields.
+func portfolioSelection(cmd *cobra.Command, format string) (portfolio.HoldingSelection, []string, error) {
+	var sel portfolio.HoldingSelection
+	sortStr, _ := cmd.Flags().GetString("sort")
+	filters, _ := cmd.Flags().GetStringArray("filter")
+	fieldNames, _ := cmd.Flags().GetStringSlice("fields")
+	sel.Reverse, _ = cmd.Flags().GetBool("reverse")
+	sel.Top, _ = cmd.Flags().GetInt("top")
+
+	key, err := portfolio.ParseHoldingSortKey(sortStr)
+	if err != nil {
+		return sel, nil, fmt.Errorf("invalid --sort: %w", err)
+	}
+	sel.Sort = key
+	if sel.Top < 0 {
+		return sel, nil, fmt.Errorf("invalid --top: must be >= 0")
+	}
+	for _, expr := range filters {
+		f, err := portfolio.ParseHoldingFilter(expr)
+		if err != nil {
+			return sel, nil, err
+		}
+		sel.Filters = append(sel.Filters, f)
+	}
+
+	fields, err := presentation.ParseHoldingFields(fieldNames)
+	if err != nil {
+		return sel, nil, fmt.Errorf("invalid --fields: %w", err)
+	}
+	if len(fields) > 0 && format != formatText && format != formatJSON && format != formatCSV {
+		return sel, nil, fmt.Errorf("--fields supports --format text, json and csv")
+	}
+	return sel, fields, nil
+}
+
+// portfolioCSVOptions reads the CSV flags; they are rejected with other formats.
+func portfolioCSVOptions(cmd *cobra.Command, format string, fields []string) (presentation.CSVOptions, error) {
+	var opts presentation.CSVOptions
+	if format != formatCSV {
+		for _, name := range []string{"section", "delimiter", "decimal-separator"} {
+			if cmd.Flags().Changed(name) {
+				return opts, fmt.Errorf("--%s requires --format csv", name)
+			}
+		}
+		return opts, nil
+	}
+
+	section, _ := cmd.Flags().GetString("section")
+	delimiter, _ := cmd.Flags().GetString("delimiter")
+	decimal, _ := cmd.Flags().GetString("decimal-separator")
+
+	opts.Section = strings.ToLower(strings.TrimSpace(section))
+	d, err := presentation.ParseCSVDelimiter(delimiter)
+	if err != nil {
+		return opts, fmt.Errorf("invalid --delimiter: %w", err)
+	}
+	opts.Delimiter = d
+	opts.DecimalSeparator = strings.TrimSpace(decimal)
+	opts.Fields = fields
+	if err := opts.Validate(); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// portfolioSchemaVersion reads --schema-version (0 = current). Older versions are whole-report
+// shapes, so field selection is only available for the current one.
+func portfolioSchemaVersion(cmd *cobra.Command, format string, fields []string) (int, error) {
+	if !cmd.Flags().Changed("schema-version") {
+		return 0, nil
+	}
+	version, _ := cmd.Flags().GetInt("schema-version")
+	if format != formatJSON {
+		return 0, fmt.Errorf("--schema-version requires --json")
+	}
+	if err := portfolio.CheckSchemaVersion(version); err != nil {
+		return 0, fmt.Errorf("invalid --schema-version: %w", err)
+	}
+	if len(fields) > 0 && version != portfolio.SchemaVersion {
+		return 0, fmt.Errorf("--fields requires the current schema version (%d)", portfolio.SchemaVersion)
+	}
+	return version, nil
+}
+
+// renderVersionedJSON writes output in an older schema version and warns on stderr that the
+// version is deprecated.
+func renderVersionedJSON(output *portfolio.Output, version int, stdout, stderr io.Writer) error {
+	value, notice, err := portfolio.VersionedOutput(output, version)
+	if err != nil {
+		return err
+	}
+	if notice != "" {
+		if f, ok := stderr.(*os.File); ok && ui.ColorEnabled(f) {
+			fmt.Fprintln(stderr, ui.StatusWarning(notice))
+		} else {
+			fmt.Fprintln(stderr, ui.SymbolWarning+" "+notice)
+		}
+	}
+	return json.NewEncoder(stdout).Encode(value)
+}
+
+// newPortfolioService wires the service with local snapshots as valuation points when available.
+func newPortfolioService(client *trading212.Client) *portfolio.Service {
+	var opts []portfolio.Option
+	if store, err := snapshots.Open(); err == nil {
+		opts = append(opts, portfolio.WithValuations(store))
+	}
+	return portfolio.NewService(client, opts...)
+}
+
+// portfolioTimeout bounds a single report. Period reports and flow-adjusted returns page through
+// history endpoints, which are heavily rate limited.
+func portfolioTimeout(period portfolio.PeriodRange, opts portfolio.PortfolioOptions) time.Duration {
+	switch {
+	case opts.WithMWR:
+		return 5 * time.Minute
+	case !period.IsAllTime() || opts.WithFlows:
+		return 2 * time.Minute
+	}
+	return 15 * time.Second
+}
+
 func parsePeriod(fromStr, toStr string) (portfolio.PeriodRange, error) {
 	fromStr = strings.TrimSpace(fromStr)
 	toStr = strings.TrimSpace(toStr)
@@ -104,8 +287,25 @@ func parsePeriod(fromStr, toStr string) (portfolio.PeriodRange, error) {
 }
 
 func init() {
-	portfolioCmd.Flags().Bool("json", false, "Output raw JSON")
+	portfolioCmd.Flags().Bool("json", false, "Output raw JSON (same as --format json)")
+	portfolioCmd.Flags().Int("schema-version", portfolio.SchemaVersion, "JSON schema version to output; pin an older version to keep scripts working after a shape change (see 'folio212 schema')")
+	portfolioCmd.Flags().String("format", formatText, "Output format: text, table, json, csv, markdown or html")
+	portfolioCmd.Flags().String("section", presentation.CSVSectionHoldings, "CSV section: holdings, allocation or summary")
+	portfolioCmd.Flags().String("delimiter", ",", "CSV field delimiter (one character, or tab, semicolon, pipe)")
+	portfolioCmd.Flags().String("decimal-separator", ".", "CSV decimal separator: . or , (e.g. --delimiter ';' --decimal-separator ',')")
+	portfolioCmd.Flags().String("template", "", "Render with a Go text/template: a file path or inline text containing {{...}} (see 'folio212 skill')")
+	portfolioCmd.Flags().String("sort", string(portfolio.SortByValue), "Sort holdings by value, pnl, return, ticker or opened")
+	portfolioCmd.Flags().Bool("reverse", false, "Reverse the sort order")
+	portfolioCmd.Flags().StringArray("filter", nil, "Keep holdings matching FIELD OP VALUE, e.g. currency=USD, pnl<0, pct>5 (repeatable; all must match)")
+	portfolioCmd.Flags().Int("top", 0, "Keep only the first N holdings after sorting and filtering")
+	portfolioCmd.Flags().StringSlice("fields", nil, "Holding fields to output (text, json, csv), e.g. ticker,value,pnl,pct")
+	portfolioCmd.Flags().Bool("tui", false, "Open the interactive dashboard (same as 'folio212 dashboard')")
 	portfolioCmd.Flags().Bool("include-raw", false, "Include raw API payloads in JSON output")
+	portfolioCmd.Flags().Bool("twr", false, "Compute flow-adjusted TWR from deposit/withdrawal history (requires History permission; with --from/--to, a snapshot at or before --from)")
+	portfolioCmd.Flags().Bool("mwr", false, "Compute money-weighted return (XIRR) for the account and each holding (requires History permission)")
 	portfolioCmd.Flags().String("from", "", "Reporting period start (YYYY-MM-DD)")
 	portfolioCmd.Flags().String("to", "", "Reporting period end (YYYY-MM-DD)")
+	portfolioCmd.Flags().Bool("all-profiles", false, "Consolidate every configured profile into one report (holdings merged by ISIN)")
+	portfolioCmd.Flags().String("currency", "", "Reporting currency for --all-profiles (default: the default profile's account currency)")
+	portfolioCmd.Flags().StringSlice("fx", nil, "FX rate for --all-profiles as CCY=RATE in reporting currency per 1 CCY (repeatable; overrides rates implied by position prices)")
 }
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+func TestRenderVersionedJSON(t *testing.T) {
+	output, err := portfolio.NewService(fakeTrading212(t)).GetPortfolio(context.Background(), portfolio.PeriodRange{}, portfolio.PortfolioOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := renderVersionedJSON(output, portfolio.SchemaVersion, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	var head struct {
+		SchemaVersion int `json:"schemaVersion"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &head); err != nil || head.SchemaVersion != portfolio.SchemaVersion {
+		t.Fatalf("stdout schemaVersion = %d (%v), want %d:\n%s", head.SchemaVersion, err, portfolio.SchemaVersion, stdout.Bytes())
+	}
+	if err := portfolio.ValidateOutput(stdout.Bytes()); err != nil {
+		t.Errorf("stdout does not match its schema: %v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want no notice for the current version", stderr.String())
+	}
+
+	stdout.Reset()
+	err = renderVersionedJSON(output, portfolio.SchemaVersion+1, &stdout, &stderr)
+	if !errors.Is(err, portfolio.ErrUnsupportedSchemaVersion) {
+		t.Errorf("newer version: err = %v", err)
+	}
+	if stdout.Len() != 0 || stderr.Len() != 0 {
+		t.Errorf("newer version wrote %q / %q", stdout.String(), stderr.String())
+	}
+}
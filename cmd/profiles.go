@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+)
+
+// consolidatedOptions configures --all-profiles reports.
+type consolidatedOptions struct {
+	currency string             // reporting currency; empty uses the default profile's account currency
+	fxRates  map[string]float64 // explicit rates from --fx
+}
+
+// fetchAllProfiles fetches every configured profile and merges the reports into one.
+// Accounts are fetched one after another; each profile has its own API key and rate limits.
+func fetchAllProfiles(ctx context.Context, period portfolio.PeriodRange, opts portfolio.PortfolioOptions, copts consolidatedOptions) (*portfolio.Output, error) {
+	cfg := GetConfig()
+	if cfg == nil {
+		return nil, fmt.Errorf("%s", presentation.HumanizeDomainError(portfolio.ErrConfigNotLoaded))
+	}
+	names := cfg.ProfileNames()
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no profiles configured; run 'folio212 init --profile NAME'")
+	}
+
+	accounts := make([]portfolio.AccountOutput, 0, len(names))
+	for _, name := range names {
+		profile, err := cfg.Profile(name)
+		if err != nil {
+			return nil, err
+		}
+		client, err := newTrading212ClientForProfile(profile)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+		output, err := newPortfolioService(client).GetPortfolio(ctx, period, opts)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+		accounts = append(accounts, portfolio.AccountOutput{Profile: name, Output: output})
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(copts.currency))
+	if currency == "" {
+		currency = accounts[0].Output.Summary.Currency
+		defaultName := cfg.ProfileName("")
+		for _, a := range accounts {
+			if a.Profile == defaultName {
+				currency = a.Output.Summary.Currency
+			}
+		}
+	}
+
+	rates := portfolio.NewFXRates(currency)
+	for ccy, rate := range copts.fxRates {
+		rates.Set(ccy, rate)
+	}
+	for _, a := range accounts {
+		rates.ObserveHoldings(a.Output.Holdings)
+	}
+
+	output, err := portfolio.Consolidate(accounts, rates, time.Now(), period)
+	if err != nil {
+		return nil, presentation.HumanizeFXError(err)
+	}
+	return output, nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/targets"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/spf13/cobra"
+)
+
+var rebalanceCmd = &cobra.Command{
+	Use:   "rebalance",
+	Short: "Propose trades to reach target weights",
+	Long: "Compares the current allocation with the target weights in ~/.folio212/targets.yaml " +
+		"(targets-<profile>.yaml for named profiles, if present) and proposes buy/sell amounts and share quantities.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		path, _ := cmd.Flags().GetString("targets")
+		buyOnly, _ := cmd.Flags().GetBool("buy-only")
+		minTrade, _ := cmd.Flags().GetFloat64("min-trade")
+
+		if minTrade < 0 {
+			return fmt.Errorf("--min-trade must not be negative")
+		}
+		opts := portfolio.RebalanceOptions{BuyOnly: buyOnly, MinTrade: minTrade}
+		if cmd.Flags().Changed("cash") {
+			cash, _ := cmd.Flags().GetFloat64("cash")
+			if cash < 0 {
+				return fmt.Errorf("--cash must not be negative")
+			}
+			opts.Cash = &cash
+		}
+
+		if path == "" {
+			profile, err := GetProfile()
+			if err != nil {
+				return err
+			}
+			if path, err = targets.DefaultPath(profile.Name); err != nil {
+				return err
+			}
+		}
+		t, err := targets.Load(path)
+		if err != nil {
+			return err
+		}
+
+		client, err := newTrading212Client()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		svc := portfolio.NewService(client)
+		output, err := svc.GetPortfolio(ctx, portfolio.PeriodRange{}, portfolio.PortfolioOptions{})
+		if err != nil {
+			return presentation.HumanizeAccountError(err)
+		}
+
+		plan, err := portfolio.PlanRebalance(output, t, opts, time.Now())
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			return enc.Encode(plan)
+		}
+		return presentation.RenderRebalanceText(plan, os.Stdout)
+	},
+}
+
+func init() {
+	rebalanceCmd.Flags().Bool("json", false, "Output JSON")
+	rebalanceCmd.Flags().String("targets", "", "Targets file (default: ~/.folio212/targets.yaml)")
+	rebalanceCmd.Flags().Bool("buy-only", false, "Only propose buys, funded by free cash")
+	rebalanceCmd.Flags().Float64("min-trade", 0, "Skip trades smaller than this amount (account currency)")
+	rebalanceCmd.Flags().Float64("cash", 0, "Cash to invest instead of the account's free cash (e.g. an upcoming deposit)")
+}
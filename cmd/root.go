@@ -1,22 +1,32 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
 	"github.com/nezdemkovski/folio212/internal/infrastructure/config"
+	"github.com/nezdemkovski/folio212/internal/presentation"
 	"github.com/nezdemkovski/folio212/internal/shared/ui"
 	"github.com/spf13/cobra"
 )
 
 var cfg *config.Config
 
+// profileFlag is the raw --profile value; empty selects the configured default profile.
+var profileFlag string
+
+const profileEnvVar = "FOLIO212_PROFILE"
+
 var rootCmd = &cobra.Command{
 	Use:   "folio212",
 	Short: "Trading212 portfolio checker",
 	Long:  "Connects to Trading212 and checks your portfolio from the terminal.",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Commands that must work without prior setup / config file.
-		if cmd.Name() == "init" || cmd.Name() == "skill" {
+		if cmd.Name() == "init" || cmd.Name() == "skill" || cmd.Name() == "schema" {
 			return nil
 		}
 
@@ -26,22 +36,70 @@ var rootCmd = &cobra.Command{
 			return fmt.Errorf("configuration not found. Please run 'folio212 init' first: %w", err)
 		}
 
+		if _, err := GetProfile(); err != nil {
+			return fmt.Errorf("%w. Run 'folio212 init --profile NAME' to create it", err)
+		}
 		return nil
 	},
 }
 
+// exitCodeError ends the process with a specific exit code without printing anything further;
+// commands use it when they have already written their output (e.g. check violations).
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		ui.ExitWithError("Command failed", err)
 	}
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Config profile to use (default: default_profile from config, or \"default\"; env FOLIO212_PROFILE)")
+
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(portfolioCmd)
+	rootCmd.AddCommand(dashboardCmd)
+	rootCmd.AddCommand(ordersCmd)
+	rootCmd.AddCommand(dividendsCmd)
+	rootCmd.AddCommand(transactionsCmd)
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(rebalanceCmd)
+	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(notifyCmd)
+	rootCmd.AddCommand(mcpCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(skillCmd)
 }
 
 func GetConfig() *config.Config {
 	return cfg
 }
+
+// ProfileName returns the requested profile name: --profile, then FOLIO212_PROFILE, else empty.
+func ProfileName() string {
+	if name := strings.ToLower(strings.TrimSpace(profileFlag)); name != "" {
+		return name
+	}
+	return strings.ToLower(strings.TrimSpace(os.Getenv(profileEnvVar)))
+}
+
+// GetProfile resolves the selected profile from the loaded config.
+func GetProfile() (config.Profile, error) {
+	if cfg == nil {
+		return config.Profile{}, fmt.Errorf("%s", presentation.HumanizeDomainError(portfolio.ErrConfigNotLoaded))
+	}
+	return cfg.Profile(ProfileName())
+}
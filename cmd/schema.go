@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of 'portfolio --json'",
+	Long: "Prints the JSON Schema (draft 2020-12) of the portfolio JSON output, generated from the report types. " +
+		"With --validate, checks a document against it instead (e.g. folio212 portfolio --json | folio212 schema --validate -).",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		version, _ := cmd.Flags().GetInt("version")
+		validatePath, _ := cmd.Flags().GetString("validate")
+
+		if validatePath != "" {
+			data, err := readInput(validatePath)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("version") {
+				err = portfolio.ValidateOutputVersion(data, version)
+			} else {
+				err = portfolio.ValidateOutput(data)
+			}
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), "valid")
+			return err
+		}
+
+		s, err := portfolio.OutputSchema(version)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(s)
+	},
+}
+
+// readInput reads a file, or stdin for "-".
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+func init() {
+	schemaCmd.Flags().Int("version", portfolio.SchemaVersion, "Schema version to print or validate against (with --validate, defaults to the document's schemaVersion)")
+	schemaCmd.Flags().String("validate", "", "Validate a portfolio JSON document (file path, or - for stdin) instead of printing the schema")
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/snapshots"
+	"github.com/nezdemkovski/folio212/internal/presentation/httpapi"
+	"github.com/nezdemkovski/folio212/internal/shared/ui"
+	"github.com/spf13/cobra"
+)
+
+// serveTokenEnvVar supplies the bearer token without exposing it in the process list.
+const serveTokenEnvVar = "FOLIO212_SERVE_TOKEN"
+
+// serveShutdownTimeout bounds how long in-flight requests may finish after SIGINT/SIGTERM.
+const serveShutdownTimeout = 10 * time.Second
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the portfolio as a local read-only JSON API and Prometheus metrics",
+	Long: "Runs an HTTP server exposing the portfolio report, holdings, allocation and stored snapshots as JSON, " +
+		"and gauges at /metrics. Reports are cached and refreshed at most once per --cache-ttl, however many clients poll.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+		minPoll, _ := cmd.Flags().GetDuration("min-poll-interval")
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token = os.Getenv(serveTokenEnvVar)
+		}
+		token = strings.TrimSpace(token)
+
+		if minPoll < httpapi.MinPollInterval {
+			return fmt.Errorf("invalid --min-poll-interval: must be at least %s (Trading212 rate limits)", httpapi.MinPollInterval)
+		}
+		if ttl < minPoll {
+			return fmt.Errorf("invalid --cache-ttl: must be at least --min-poll-interval (%s)", minPoll)
+		}
+
+		client, err := newTrading212Client()
+		if err != nil {
+			return err
+		}
+		svc := newPortfolioService(client)
+		cache := httpapi.NewCache(func(ctx context.Context) (*portfolio.Output, error) {
+			ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+			defer cancel()
+			return svc.GetPortfolio(ctx, portfolio.PeriodRange{}, portfolio.PortfolioOptions{})
+		}, ttl, httpapi.WithMinPollInterval(minPoll))
+
+		opts := []httpapi.Option{httpapi.WithToken(token)}
+		if store, err := snapshots.Open(); err == nil {
+			// Only the selected profile's snapshots are exposed.
+			accountID, err := snapshotAccountID(cmd)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, ui.StatusWarning(fmt.Sprintf("snapshot endpoints disabled: %v", err)))
+			} else {
+				opts = append(opts, httpapi.WithSnapshots(store, accountID))
+			}
+		}
+
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+		if token == "" && !isLoopback(ln.Addr()) {
+			fmt.Fprintln(os.Stderr, ui.StatusWarning(fmt.Sprintf("listening on %s without a token; set --token or %s", ln.Addr(), serveTokenEnvVar)))
+		}
+
+		srv := &http.Server{
+			Handler:           httpapi.NewHandler(cache, opts...),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- srv.Serve(ln)
+		}()
+		fmt.Fprintf(os.Stderr, "Serving on http://%s (cache %s)\n", ln.Addr(), cache.TTL())
+
+		select {
+		case err := <-errCh:
+			return err
+		case <-ctx.Done():
+		}
+		fmt.Fprintln(os.Stderr, "Shutting down...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+func isLoopback(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
+
+func init() {
+	serveCmd.Flags().String("addr", "127.0.0.1:8212", "Listen address (host:port)")
+	serveCmd.Flags().Duration("cache-ttl", time.Minute, "Refresh interval: requests and scrapes reuse a report for this long")
+	serveCmd.Flags().Duration("min-poll-interval", httpapi.DefaultPollInterval, "Minimum time between Trading212 requests, failed refreshes included (at least 5s)")
+	serveCmd.Flags().String("token", "", "Require 'Authorization: Bearer TOKEN' (default: $"+serveTokenEnvVar+")")
+}
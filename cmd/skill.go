@@ -41,7 +41,15 @@ Commands
 
 - Interactive setup (required before most other commands).
 - Collects Trading212 API key + secret from the user and validates access.
-- Usage: ` + "`folio212 init`" + `
+- Usage:
+  - ` + "`folio212 init`" + `
+  - ` + "`folio212 init --profile isa`" + ` (add or update a named profile for another account)
+
+Profiles
+
+- ` + "`config.yaml`" + ` can hold several Trading212 accounts as named profiles; the top-level settings are the ` + "`default`" + ` profile.
+- Every command accepts the global ` + "`--profile NAME`" + ` flag (or ` + "`FOLIO212_PROFILE`" + `); without it ` + "`default_profile`" + ` from config is used, else ` + "`default`" + ` The first ` + "`init --profile NAME`" + ` on a fresh install sets ` + "`default_profile`" + ` to it.
+- Each profile has its own secret: keyring key ` + "`t212-api-secret-<profile>`" + `, env ` + "`FOLIO212_T212_API_SECRET_<PROFILE>`" + ` (e.g. ` + "`FOLIO212_T212_API_SECRET_ISA`" + `).
 
 ` + "`folio212 portfolio`" + ` (alias: ` + "`positions`" + `)
 
@@ -51,20 +59,214 @@ Commands
   - ` + "`folio212 positions`" + `
 - Flags:
   - ` + "`--json`" + `: output a single JSON object (schema versioned)
+  - ` + "`--format text|table|json|csv|markdown|html`" + ` (` + "`--json`" + ` is the same as ` + "`--format json`" + `; ` + "`md`" + ` is accepted for ` + "`markdown`" + `)
+  - ` + "`table`" + `: compact aligned table for humans (ticker, name, qty, avg, price, value, uPnL, return, weight) plus a one-line account summary; fits the terminal width (` + "`COLUMNS`" + ` overrides) by shortening names and dropping columns; colour only on a TTY without ` + "`NO_COLOR`" + `
+  - ` + "`markdown`" + `/` + "`html`" + `: self-contained report (summary tables, allocation with bars, holdings table, reconciliation warnings); HTML is one file with inline CSS and no external assets
+  - CSV: ` + "`--section holdings|allocation|summary`" + ` (default ` + "`holdings`" + `; ` + "`summary`" + ` is one row), ` + "`--delimiter`" + ` (character or ` + "`tab`" + `, ` + "`semicolon`" + `, ` + "`pipe`" + `), ` + "`--decimal-separator .|,`" + `
+    - Header order is stable (snake_case, e.g. ` + "`ticker,name,isin,...,market_value,unrealized_pnl,...`" + `); new columns are only appended
+    - Money has 2 decimals, quantities/prices full precision; optional values (e.g. ` + "`fx_impact`" + `) are empty
+    - EU spreadsheets: ` + "`folio212 portfolio --format csv --delimiter ';' --decimal-separator ','`" + `
+  - ` + "`--template FILE|TEXT`" + `: render with Go text/template (inline when the value contains ` + "`{{`" + `); dot is the same object as ` + "`--json`" + `
+    - Helpers: ` + "`money`" + ` (1,234.56), ` + "`signed`" + ` (+12.30), ` + "`num N`" + `, ` + "`pct`" + `, ` + "`signedPct`" + `, ` + "`bps`" + `, ` + "`symbol CCY`" + ` (€), ` + "`padLeft N`" + `, ` + "`padRight N`" + `, ` + "`sortHoldings KEY`" + ` (value|pnl|return|ticker|opened, ` + "`-KEY`" + ` reverses), ` + "`top N`" + `, ` + "`upper`" + `, ` + "`lower`" + `, ` + "`join`" + `, ` + "`default`" + `, ` + "`json`" + `
+    - Numeric helpers accept optional fields (e.g. ` + "`.FXImpact`" + `); missing values print ` + "`n/a`" + `
+    - Example: ` + "`folio212 portfolio --template '{{ symbol .Summary.Currency }}{{ money .Summary.Derived.AccountTotal }}{{ range top 3 (sortHoldings \"pnl\" .Holdings) }} {{ .Ticker }}{{ end }}'`" + `
+  - Holding selection (applies to every format; summary totals still cover the whole account, allocation follows the selected holdings):
+    - ` + "`--sort value|pnl|return|ticker|opened`" + ` (default ` + "`value`" + `; numbers largest first, ticker A-Z, opened oldest first), ` + "`--reverse`" + `
+    - ` + "`--filter EXPR`" + ` (repeatable, all must match): ` + "`FIELD OP VALUE`" + ` with fields ` + "`ticker`" + `, ` + "`name`" + `, ` + "`isin`" + `, ` + "`currency`" + ` (` + "`=`" + `/` + "`!=`" + `, case-insensitive) and ` + "`value`" + `, ` + "`pnl`" + `, ` + "`return`" + `, ` + "`pct`" + `, ` + "`qty`" + `, ` + "`invested`" + ` (` + "`=`" + `, ` + "`!=`" + `, ` + "`<`" + `, ` + "`<=`" + `, ` + "`>`" + `, ` + "`>=`" + `)
+    - ` + "`--top N`" + `: first N holdings after filtering and sorting
+    - ` + "`--fields a,b,c`" + `: holding columns for text (table), JSON (holdings only carry these keys, in order) and CSV; names are the CSV header names, JSON keys or aliases ` + "`value`" + `, ` + "`pnl`" + `, ` + "`pct`" + `, ` + "`currency`" + `, ` + "`price`" + `, ` + "`avg_price`" + `, ` + "`opened`" + `, ` + "`mwr`" + `
+    - Example: ` + "`folio212 portfolio --json --filter currency=USD --filter pnl<0 --sort pnl --reverse --fields ticker,value,pnl`" + `
+  - ` + "`--tui`" + `: open the interactive dashboard (see ` + "`folio212 dashboard`" + `)
   - ` + "`--include-raw`" + `: include raw Trading212 payloads in JSON output (only meaningful with ` + "`--json`" + `)
-  - ` + "`--from YYYY-MM-DD`" + ` and ` + "`--to YYYY-MM-DD`" + `: label a reporting period
+  - ` + "`--schema-version N`" + `: JSON output in schema version N (default: current; 1 is the only version so far). After an incompatible change, older versions are produced by adapters from the current report and print a deprecation notice on stderr; pin a version in scripts to survive shape changes. Not combinable with ` + "`--fields`" + ` for older versions
+  - ` + "`--twr`" + `: estimate account TWR from deposit/withdrawal history (needs ` + "**History**" + `)
+    - Modified Dietz approximation, not a true TWR: flows are weighted by time invested and the period is split only at stored valuations (` + "`twrMethod`" + `: ` + "`modified-dietz-linked`" + `, or ` + "`modified-dietz-single-period`" + ` when none falls inside it)
+    - ` + "`--from/--to`" + ` periods are measured from the last stored valuation at or before ` + "`--from`" + ` (and ` + "`--to`" + ` if in the past); ` + "`twrDescription`" + ` names the window
+    - Without it (or if history is unavailable) ` + "`twrMethod`" + ` is ` + "`holdings-only-no-flows`" + ` and ` + "`twrPctEst`" + ` equals the holdings return
+  - ` + "`--mwr`" + `: money-weighted return (annualized XIRR since inception) as ` + "`summary.derived.mwrPct`" + ` and per holding ` + "`mwrPct`" + ` (needs ` + "**History**" + `; slow: pages orders, dividends and transactions)
+  - ` + "`--from YYYY-MM-DD`" + ` and ` + "`--to YYYY-MM-DD`" + `: reporting period; adds period flows (executed buys/sells/fees)
     - Must provide both; format must be ` + "`YYYY-MM-DD`" + `
     - ` + "`--to`" + ` must be >= ` + "`--from`" + `
+    - Period flows need the ` + "**History**" + ` permission; without it the report still works and shows a warning
+  - ` + "`--all-profiles`" + `: fetch every configured profile and merge into one report
+    - Amounts are converted to ` + "`--currency`" + ` (default: the default profile's account currency); rates are implied from position prices, or set with ` + "`--fx USD=0.92`" + ` (reporting-currency units per 1 USD)
+    - Holdings are merged by ISIN; each holding has ` + "`accounts[]`" + ` (profile, qty, value) and the report has top-level ` + "`accounts[]`" + ` with each account's own ` + "`derived`" + ` and ` + "`reconcile`" + ` (account currency) plus ` + "`fxRate`" + `/` + "`fxSource`" + `
+    - TWR/MWR are not chained across accounts; the consolidated ` + "`twrMethod`" + ` is always ` + "`holdings-only-no-flows`" + `
+
+` + "`folio212 dashboard`" + ` (alias: ` + "`tui`" + `; also ` + "`folio212 portfolio --tui`" + `)
+
+- Interactive full-screen dashboard for humans (not for agents: use ` + "`portfolio --json`" + ` instead).
+- Summary header from ` + "`summary.derived`" + `, sortable holdings table, details pane for the selected holding.
+- Keys: up/down or j/k select, s cycle sort (value, pnl, return, ticker, opened), o reverse, r refresh, q quit.
+- Refreshes are spaced at least 10s apart to respect Trading212 rate limits.
+- Flags: ` + "`--twr`" + `, ` + "`--mwr`" + `, ` + "`--from`" + `, ` + "`--to`" + ` (same meaning as for ` + "`portfolio`" + `)
+
+` + "`folio212 orders`" + `
+
+- Fetches historical orders and prints executed buys and sells (newest first) with totals.
+- Usage:
+  - ` + "`folio212 orders --from 2026-01-01 --to 2026-01-31`" + `
+  - ` + "`folio212 orders --ticker AAPL_US_EQ --json`" + `
+- Flags:
+  - ` + "`--from YYYY-MM-DD`" + ` and ` + "`--to YYYY-MM-DD`" + `: limit to a period (both or neither)
+  - ` + "`--ticker`" + `: only orders for this Trading212 ticker
+  - ` + "`--json`" + `: output a single JSON object (schema versioned)
+- Requires the ` + "**History**" + ` permission. History endpoints allow ~6 requests/min, so long histories take a while.
+
+` + "`folio212 dividends`" + `
+
+- Fetches paid-out dividends and aggregates net income (plus gross/withholding when the dividend currency matches the account currency).
+- Usage:
+  - ` + "`folio212 dividends --from 2025-01-01 --to 2025-12-31 --by month`" + `
+  - ` + "`folio212 dividends --by year --json`" + `
+- Flags:
+  - ` + "`--from YYYY-MM-DD`" + ` and ` + "`--to YYYY-MM-DD`" + `: limit to a period (both or neither)
+  - ` + "`--by ticker|month|year`" + `: grouping (default ` + "`ticker`" + `)
+  - ` + "`--ticker`" + `: only dividends for this Trading212 ticker
+  - ` + "`--json`" + `: output a single JSON object (schema versioned)
+- Requires the ` + "**History**" + ` permission.
+
+` + "`folio212 transactions`" + `
+
+- Fetches cash transactions: deposits, withdrawals, interest on cash, fees and transfers, with totals per type.
+- Amounts are signed: positive = money into the account.
+- CSV ends with one total row per type: ` + "`type`" + ` is ` + "`total_deposit`" + `, ` + "`total_fee`" + `, ... and ` + "`date_time`" + ` is empty.
+- Usage:
+  - ` + "`folio212 transactions --from 2025-01-01 --to 2025-12-31`" + `
+  - ` + "`folio212 transactions --format csv > cash.csv`" + `
+- Flags:
+  - ` + "`--from YYYY-MM-DD`" + ` and ` + "`--to YYYY-MM-DD`" + `: limit to a period (both or neither)
+  - ` + "`--format text|json|csv`" + ` (` + "`--json`" + ` is a shortcut for ` + "`--format json`" + `)
+- Requires the ` + "**History**" + ` permission.
+
+` + "`folio212 snapshot`" + `
+
+- Stores full portfolio reports locally in ` + "`~/.folio212/snapshots.jsonl`" + ` (append-only, one JSON record per line, keyed by account ID + timestamp).
+- Stored snapshots are used as valuation points for ` + "`portfolio --twr`" + ` over a ` + "`--from/--to`" + ` period.
+- ` + "`snapshot list`" + `, ` + "`snapshot show`" + `, ` + "`diff`" + ` and ` + "`history`" + ` read only the selected profile's account (its ID is kept as ` + "`trading212_account_id`" + ` in the config); ` + "`--account ID`" + ` picks another account, ` + "`--all-accounts`" + ` (or ` + "`--account 0`" + `) reads all.
+- Usage:
+  - ` + "`folio212 snapshot save`" + ` (suitable for cron, e.g. daily)
+  - ` + "`folio212 snapshot list [--account ID|--all-accounts] [--json]`" + `
+  - ` + "`folio212 snapshot show [ID|latest] [--json]`" + ` (` + "`--json`" + ` has the same shape as ` + "`portfolio --json`" + `)
+
+` + "`folio212 diff`" + `
+
+- Compares two stored snapshots, or a snapshot against live data (default when the second ID is omitted).
+- Shows total deltas (` + "`summary.derived`" + `), biggest movers, quantity/value/uPnL changes, allocation drift in bps, new and closed positions.
+- Usage:
+  - ` + "`folio212 diff latest`" + ` (latest snapshot vs live)
+  - ` + "`folio212 diff <from-id> <to-id> --json`" + `
+- Flags:
+  - ` + "`--json`" + `: structured delta document (` + "`totals.*`" + ` and ` + "`holdings[]`" + ` with ` + "`before`" + `/` + "`after`" + `/` + "`delta`" + `, ` + "`change`" + `: new|closed|changed|unchanged)
+  - ` + "`--top N`" + `: movers shown in text output (default 10, 0 = all)
+
+` + "`folio212 history`" + `
+
+- Charts values from stored snapshots as terminal sparklines; prints a plain table when stdout is not a terminal (pipes/files).
+- Usage:
+  - ` + "`folio212 history --from 2026-01-01 --to 2026-06-30`" + `
+  - ` + "`folio212 history --metric total,pnl`" + `
+  - ` + "`folio212 history --ticker AAPL_US_EQ --metric value,qty`" + `
+- Flags:
+  - ` + "`--metric`" + `: comma-separated ` + "`total`" + `, ` + "`value`" + `, ` + "`cost`" + `, ` + "`pnl`" + `, ` + "`cash`" + `, ` + "`qty`" + ` (default ` + "`total,value,cost`" + `; ` + "`qty`" + ` needs ` + "`--ticker`" + `)
+  - ` + "`--ticker`" + `: plot a single holding
+  - ` + "`--json`" + `: raw series
+
+` + "`folio212 rebalance`" + `
+
+- Compares the current allocation (` + "`allocation[].holdingsBps`" + `) with target weights and proposes trades.
+- Targets file: ` + "`~/.folio212/targets.yaml`" + ` (or ` + "`targets-<profile>.yaml`" + ` for a named profile); weights in percent, must add up to 100:
+  - ` + "`targets:`" + ` list of ` + "`{ticker|isin, weight}`" + `
+  - ` + "`groups:`" + ` list of ` + "`{name, weight, members: [{ticker|isin, weight?}]}`" + ` (member weight = share of the group; omitted = equal split)
+- Full mode: moves every holding to its target share of holdings value + free cash; holdings without a target are sold.
+- Usage:
+  - ` + "`folio212 rebalance`" + `
+  - ` + "`folio212 rebalance --buy-only --min-trade 50 --json`" + `
+- Flags:
+  - ` + "`--buy-only`" + `: never sell; free cash goes to underweight holdings (scaled down when cash is short)
+  - ` + "`--min-trade N`" + `: skip trades below N (account currency)
+  - ` + "`--cash N`" + `: plan with N to invest instead of the account's free cash
+  - ` + "`--targets PATH`" + `: use another targets file
+  - ` + "`--json`" + `: ` + "`rows[]`" + ` with ` + "`currentBps`" + `, ` + "`targetBps`" + `, ` + "`driftBps`" + `, ` + "`action`" + ` (buy|sell|hold), ` + "`amount`" + `, ` + "`qty`" + ` (null when not held), plus ` + "`groups[]`" + ` and ` + "`totals`" + `
+
+` + "`folio212 check`" + `
+
+- Evaluates rules against the current portfolio for cron/schedulers. Exit codes: ` + "`0`" + ` all rules passed, ` + "`2`" + ` violations, ` + "`1`" + ` the check could not run.
+- Rules (flags, or defaults in the ` + "`check:`" + ` section of ` + "`config.yaml`" + ` with the same names in snake_case):
+  - ` + "`--max-drift-bps N`" + `: a holding or target group drifts more than N bps from its target (uses the ` + "`rebalance`" + ` targets file; ` + "`--targets PATH`" + ` to override)
+  - ` + "`--max-position-pct X`" + `: a single holding is above X% of holdings
+  - ` + "`--max-free-cash N`" + `: free cash above N (account currency)
+  - ` + "`--fail-on-reconcile`" + `: reconciliation warnings present
+- Usage:
+  - ` + "`folio212 check --max-position-pct 25 --max-free-cash 500 --json`" + `
+- ` + "`--json`" + `: ` + "`{\"ok\":bool,\"rules\":[...],\"violations\":[{\"rule\",\"ticker\",\"group\",\"value\",\"limit\",\"message\"}]}`" + ` (printed for both outcomes)
+
+` + "`folio212 notify`" + `
+
+- Posts a notification (summary, daily change vs the latest snapshot from before today, rule violations) to the webhooks in the ` + "`notify:`" + ` section of ` + "`config.yaml`" + `
+- Event is ` + "`violations`" + ` when a rule fails, otherwise ` + "`summary`" + `; webhooks can subscribe with ` + "`events: [violations]`" + `
+- Rules: same flags and ` + "`check:`" + ` defaults as ` + "`folio212 check`" + `
+- Flags:
+  - ` + "`--dry-run`" + `: print the payload (or each webhook's rendered body) instead of sending
+  - ` + "`--webhook NAME`" + `: only these webhooks (repeatable)
+  - ` + "`--only-violations`" + `: send nothing unless a rule is violated
+- Webhook ` + "`template`" + `: ` + "`slack`" + `, ` + "`discord`" + `, ` + "`ntfy`" + `, a Go text/template (helpers: ` + "`message`" + `, ` + "`money`" + `, ` + "`signed`" + `, ` + "`pct`" + `, ` + "`json`" + `, ` + "`join`" + `) or empty for the JSON payload
+- Retries network errors, ` + "`429`" + ` (honours ` + "`Retry-After`" + `) and ` + "`5xx`" + ` with 1s/2s/4s backoff; ` + "`notify.retries`" + ` changes the count
+- Usage:
+  - ` + "`folio212 notify --dry-run`" + `
+  - ` + "`folio212 notify --only-violations --max-position-pct 25`" + `
+
+` + "`folio212 mcp`" + `
+
+- Model Context Protocol server over stdio (JSON-RPC, one message per line) for MCP clients such as Claude Desktop
+- Tools (read-only): ` + "`get_portfolio`" + ` (from, to, twr, mwr, sort, reverse, filter, top, fields), ` + "`get_holding`" + ` (ticker or isin), ` + "`list_orders`" + ` (from, to, ticker, limit), ` + "`get_dividends`" + ` (from, to, ticker, by)
+- Arguments are validated strictly: unknown or mistyped arguments are rejected with JSON-RPC error ` + "`-32602`" + `; API failures come back as tool results with ` + "`isError: true`" + `
+- Resources: ` + "`folio212://skill`" + ` (this reference), ` + "`folio212://schema/portfolio`" + ` (JSON Schema of ` + "`get_portfolio`" + `)
+- Flags:
+  - ` + "`--allow-write`" + `: also expose ` + "`save_snapshot`" + ` (appends to the local snapshot log; never trades)
+- Usage:
+  - ` + "`folio212 mcp`" + `
+  - ` + "`folio212 --profile isa mcp`" + `
+
+` + "`folio212 serve`" + `
+
+- Local read-only JSON API; all responses are JSON, errors are ` + "`{\"error\": \"...\"}`" + `
+- Endpoints (GET): ` + "`/api/v1/portfolio`" + ` (same as ` + "`portfolio --json`" + `), ` + "`/api/v1/holdings`" + ` (query ` + "`sort`" + `, ` + "`reverse`" + `, ` + "`filter`" + ` (repeatable), ` + "`top`" + `), ` + "`/api/v1/holdings/{ticker or ISIN}`" + `, ` + "`/api/v1/allocation`" + `, ` + "`/api/v1/snapshots`" + ` (the selected profile's account only), ` + "`/api/v1/snapshots/{id|latest}`" + `, ` + "`/healthz`" + `
+- The report is cached and refreshed at most once per ` + "`--cache-ttl`" + `; on a failed refresh the previous report is served with ` + "`X-Folio212-Stale: true`" + `; ` + "`X-Folio212-Fetched-At`" + ` is the fetch time
+- ` + "`/metrics`" + `: Prometheus gauges ` + "`folio212_account_total`" + `, ` + "`folio212_free_cash`" + `, ` + "`folio212_pie_cash`" + `, ` + "`folio212_holdings_value`" + `, ` + "`folio212_holdings_cost`" + `, ` + "`folio212_holdings_unrealized_pnl`" + ` (label ` + "`currency`" + `), per holding ` + "`folio212_holding_market_value`" + `, ` + "`folio212_holding_quantity`" + `, ` + "`folio212_holding_weight_percent`" + ` (labels ` + "`ticker`" + `, ` + "`name`" + `, ` + "`isin`" + `), plus ` + "`folio212_up`" + ` and ` + "`folio212_report_stale`" + `; a scrape refreshes the report once it is older than ` + "`--cache-ttl`" + `
+- Without any report yet: ` + "`502`" + ` on API errors, ` + "`503`" + ` with ` + "`Retry-After`" + ` when Trading212 rate limits
+- Flags:
+  - ` + "`--addr HOST:PORT`" + `: listen address (default ` + "`127.0.0.1:8212`" + `)
+  - ` + "`--cache-ttl DURATION`" + `: refresh interval; requests and scrapes reuse a report this long (default ` + "`1m`" + `)
+  - ` + "`--min-poll-interval DURATION`" + `: minimum time between Trading212 requests, failed refreshes included (default ` + "`10s`" + `, at least ` + "`5s`" + `)
+  - ` + "`--token TOKEN`" + `: require ` + "`Authorization: Bearer TOKEN`" + ` (default ` + "`$FOLIO212_SERVE_TOKEN`" + `; ` + "`/healthz`" + ` stays open)
+- Usage:
+  - ` + "`folio212 serve`" + `
+  - ` + "`curl -s localhost:8212/api/v1/holdings?sort=pnl&top=5`" + `
+
+` + "`folio212 schema`" + `
+
+- Prints the JSON Schema (draft 2020-12) of ` + "`portfolio --json`" + `, generated from the report types; works without config
+- Fields that can be unavailable (e.g. ` + "`fxImpact`" + `, ` + "`holdingsFxImpact`" + `, ` + "`mwrPct`" + `, ` + "`periodFlows`" + `, ` + "`raw`" + `) are nullable; objects reject unknown properties
+- ` + "`--fields`" + ` output validates too: its holdings match ` + "`HoldingRowFields`" + ` (only the selected keys, each nullable)
+- Flags:
+  - ` + "`--version N`" + `: schema version (default: current, see ` + "`schemaVersion`" + `; any version accepted by ` + "`portfolio --schema-version`" + `)
+  - ` + "`--validate FILE`" + `: validate a document (` + "`-`" + ` for stdin) against the schema of its ` + "`schemaVersion`" + `; exits non-zero listing mismatches as JSON Pointer paths
+- Usage:
+  - ` + "`folio212 schema > portfolio.schema.json`" + `
+  - ` + "`folio212 portfolio --json | folio212 schema --validate -`" + `
 
 Trading212 API key permissions
 
 - Required: ` + "**Account data**" + `, ` + "**Portfolio**" + `
 - Optional (recommended): ` + "**Metadata**" + `
+- Optional: ` + "**History**" + ` (required for ` + "`folio212 orders`" + `, ` + "`folio212 dividends`" + ` and ` + "`folio212 transactions`" + `)
 
 Troubleshooting (common)
 
 - ` + "`403`" + ` on account summary: missing ` + "**Account data**" + ` permission
 - ` + "`403`" + ` on positions: missing ` + "**Portfolio**" + ` permission
+- ` + "`403`" + ` on orders: missing ` + "**History**" + ` permission
 - ` + "`429`" + `: rate limited; retry in a bit
 
 Example output (plain text)
@@ -112,15 +314,16 @@ Report date: YYYY-MM-DD
 Reporting period: 2026-01-01 -> 2026-01-31
 ...
 Period flows (executed trades, <CURRENCY>)
-  buys: 0.00
-  sells: 0.00
-  net: 0.00
-  Note: This is not implemented yet (requires History - Orders permission).
+  orders: <N>
+  buys: <AMOUNT>
+  sells: <AMOUNT>
+  fees: <AMOUNT>
+  net (buys - sells): <AMOUNT>
 ` + "```" + `
 
 ` + "```text" + `
 $ folio212 portfolio --from 2026-01-01 --to 2026-01-31 --json
-{"schemaVersion":1,"report":{"reportDate":"YYYY-MM-DD","generatedAt":"RFC3339","period":{"from":"2026-01-01","to":"2026-01-31"}},"summary":{"currency":"<CURRENCY>","derived":{"holdingsValue":<N>,"pieCash":<N>,"allocated":<N>,"freeCash":<N>,"accountTotal":<N>,"holdingsCost":<N>,"holdingsPnL":<N>,"holdingsFxImpact":<N>,"holdingsPnLExclFx":<N>,"holdingsReturnPct":<N>,"holdingsReturnBps":<N>,"twrPctEst":<N>,"twrBpsEst":<N>,"twrMethod":"holdings-only-no-flows"},"snapshot":{"apiInvestmentsValue":<N>,"apiCashInPies":<N>,"apiCashAvailable":<N>,"apiCashReserved":<N>,"apiRealizedPnL":<N>,"apiTotalCost":<N>,"apiTotalValue":<N>},"reconcile":{"allocatedDiff":<N>,"accountTotalDiff":<N>}},"allocation":[{"ticker":"<TICKER>","marketValue":<N>,"holdingsPct":<N>,"holdingsBps":<N>}],"holdings":[{"ticker":"<TICKER>","name":"<InstrumentName>","isin":"<ISIN>","openedAt":"RFC3339","qty":<N>,"tradableQty":<N>,"qtyInPies":<N>,"instrumentCurrency":"<CCY>","avgPricePaid":<N>,"currentPrice":<N>,"accountCurrency":"<CURRENCY>","invested":<N>,"marketValue":<N>,"unrealizedPnL":<N>,"fxImpact":<N>,"fxPair":"<FXPAIR>","holdingsPct":<N>,"holdingsBps":<N>}]}
+{"schemaVersion":1,"report":{"reportDate":"YYYY-MM-DD","generatedAt":"RFC3339","period":{"from":"2026-01-01","to":"2026-01-31"}},"summary":{"currency":"<CURRENCY>","derived":{"holdingsValue":<N>,"pieCash":<N>,"allocated":<N>,"freeCash":<N>,"accountTotal":<N>,"holdingsCost":<N>,"holdingsPnL":<N>,"holdingsFxImpact":<N>,"holdingsPnLExclFx":<N>,"holdingsReturnPct":<N>,"holdingsReturnBps":<N>,"twrPctEst":<N>,"twrBpsEst":<N>,"twrMethod":"holdings-only-no-flows"},"snapshot":{"apiInvestmentsValue":<N>,"apiCashInPies":<N>,"apiCashAvailable":<N>,"apiCashReserved":<N>,"apiRealizedPnL":<N>,"apiTotalCost":<N>,"apiTotalValue":<N>},"reconcile":{"allocatedDiff":<N>,"accountTotalDiff":<N>}},"periodFlows":{"available":true,"orderCount":<N>,"buys":<N>,"sells":<N>,"fees":<N>,"net":<N>},"allocation":[{"ticker":"<TICKER>","marketValue":<N>,"holdingsPct":<N>,"holdingsBps":<N>}],"holdings":[{"ticker":"<TICKER>","name":"<InstrumentName>","isin":"<ISIN>","openedAt":"RFC3339","qty":<N>,"tradableQty":<N>,"qtyInPies":<N>,"instrumentCurrency":"<CCY>","avgPricePaid":<N>,"currentPrice":<N>,"accountCurrency":"<CURRENCY>","invested":<N>,"marketValue":<N>,"unrealizedPnL":<N>,"fxImpact":<N>,"fxPair":"<FXPAIR>","holdingsPct":<N>,"holdingsBps":<N>}]}
 ` + "```" + `
 
 Safety
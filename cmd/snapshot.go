@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/config"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/snapshots"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save and browse local portfolio snapshots",
+	Long:  "Stores full portfolio reports in ~/.folio212/snapshots.jsonl so they can be compared over time.",
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save",
+	Short: "Fetch the portfolio and store a snapshot",
+	Long:  "Fetches the current portfolio and appends it to the local snapshot log. Suitable for cron.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		store, err := snapshots.Open()
+		if err != nil {
+			return err
+		}
+
+		client, err := newTrading212Client()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		svc := portfolio.NewService(client)
+		output, err := svc.GetPortfolio(ctx, portfolio.PeriodRange{}, portfolio.PortfolioOptions{})
+		if err != nil {
+			return presentation.HumanizeAccountError(err)
+		}
+
+		rec, err := store.Save(output, time.Now())
+		if err != nil {
+			return err
+		}
+		if profile, err := GetProfile(); err == nil {
+			rememberAccountID(profile, rec.AccountID)
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			return enc.Encode(presentation.NewSnapshotSummary(*rec))
+		}
+		_, err = fmt.Fprintf(os.Stdout, "Saved snapshot %s (%.2f %s) to %s\n",
+			rec.ID, output.Summary.Derived.AccountTotal, output.Summary.Currency, store.Path())
+		return err
+	},
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored snapshots",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		accountID, err := snapshotAccountID(cmd)
+		if err != nil {
+			return err
+		}
+
+		store, err := snapshots.Open()
+		if err != nil {
+			return err
+		}
+		records, err := store.List(accountID)
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			summaries := make([]presentation.SnapshotSummary, 0, len(records))
+			for _, r := range records {
+				summaries = append(summaries, presentation.NewSnapshotSummary(r))
+			}
+			enc := json.NewEncoder(os.Stdout)
+			return enc.Encode(summaries)
+		}
+		return presentation.RenderSnapshotList(records, os.Stdout)
+	},
+}
+
+var snapshotShowCmd = &cobra.Command{
+	Use:   "show <id|latest>",
+	Short: "Show a stored snapshot",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		accountID, err := snapshotAccountID(cmd)
+		if err != nil {
+			return err
+		}
+
+		id := snapshots.IDLatest
+		if len(args) == 1 {
+			id = args[0]
+		}
+
+		store, err := snapshots.Open()
+		if err != nil {
+			return err
+		}
+		rec, err := store.Get(id, accountID)
+		if err != nil {
+			return err
+		}
+		if rec.Output == nil {
+			return fmt.Errorf("snapshot %s has no portfolio data", rec.ID)
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			return enc.Encode(rec.Output)
+		}
+		return presentation.RenderPortfolioText(rec.Output, os.Stdout)
+	},
+}
+
+// addSnapshotAccountFlags adds the flags read by snapshotAccountID.
+func addSnapshotAccountFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64("account", 0, "Trading212 account ID to read snapshots for (default: the selected profile's account; 0 = all)")
+	cmd.Flags().Bool("all-accounts", false, "Read snapshots of every account, not just the selected profile's")
+}
+
+// snapshotAccountID returns the account whose snapshots a command reads: --account when given
+// (0 = all accounts), 0 with --all-accounts, otherwise the selected profile's account. A profile
+// whose account ID is not known yet is resolved with one API call and remembered in the config.
+func snapshotAccountID(cmd *cobra.Command) (int64, error) {
+	if all, _ := cmd.Flags().GetBool("all-accounts"); all {
+		return 0, nil
+	}
+	if cmd.Flags().Changed("account") {
+		return cmd.Flags().GetInt64("account")
+	}
+
+	profile, err := GetProfile()
+	if err != nil {
+		return 0, err
+	}
+	if profile.Trading212AccountID != 0 {
+		return profile.Trading212AccountID, nil
+	}
+
+	client, err := newTrading212ClientForProfile(profile)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	summary, err := client.GetAccountSummary(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot look up the account of profile %s (pass --account ID or --all-accounts): %w",
+			profile.Name, presentation.HumanizeAccountError(err))
+	}
+	rememberAccountID(profile, summary.ID)
+	return summary.ID, nil
+}
+
+// rememberAccountID records a profile's account ID in the config file. It is a cache: a failed
+// write only means the ID is looked up again next time.
+func rememberAccountID(profile config.Profile, accountID int64) {
+	c := GetConfig()
+	if c == nil || accountID == 0 || profile.Trading212AccountID == accountID {
+		return
+	}
+	profile.Trading212AccountID = accountID
+	c.SetProfile(profile.Name, profile)
+	_ = config.Save(c)
+}
+
+func init() {
+	snapshotSaveCmd.Flags().Bool("json", false, "Output the saved snapshot summary as JSON")
+	snapshotListCmd.Flags().Bool("json", false, "Output raw JSON")
+	addSnapshotAccountFlags(snapshotListCmd)
+	snapshotShowCmd.Flags().Bool("json", false, "Output raw JSON (same shape as 'portfolio --json')")
+	addSnapshotAccountFlags(snapshotShowCmd)
+
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+	snapshotCmd.AddCommand(snapshotShowCmd)
+}
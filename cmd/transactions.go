@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+	"github.com/spf13/cobra"
+)
+
+var transactionsCmd = &cobra.Command{
+	Use:   "transactions",
+	Short: "Show deposits, withdrawals, interest and fees",
+	Long:  "Fetches the account's cash transaction history from Trading212 and prints it with totals per type.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromStr, _ := cmd.Flags().GetString("from")
+		toStr, _ := cmd.Flags().GetString("to")
+
+		format, err := outputFormat(cmd, formatText, formatJSON, formatCSV)
+		if err != nil {
+			return err
+		}
+
+		period, err := parsePeriod(fromStr, toStr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", presentation.HumanizeDomainError(portfolio.ErrInvalidPeriod), err)
+		}
+
+		client, err := newTrading212Client()
+		if err != nil {
+			return err
+		}
+
+		// History endpoints are paginated and heavily rate limited; allow time for several pages.
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		svc := portfolio.NewService(client)
+		output, err := svc.GetTransactions(ctx, period)
+		if err != nil {
+			return presentation.HumanizeHistoryError(err)
+		}
+
+		switch format {
+		case formatJSON:
+			enc := json.NewEncoder(os.Stdout)
+			return enc.Encode(output)
+		case formatCSV:
+			return presentation.RenderTransactionsCSV(output, os.Stdout)
+		}
+
+		return presentation.RenderTransactionsText(output, os.Stdout)
+	},
+}
+
+func init() {
+	transactionsCmd.Flags().Bool("json", false, "Output raw JSON (same as --format json)")
+	transactionsCmd.Flags().String("format", formatText, "Output format: text, json or csv")
+	transactionsCmd.Flags().String("from", "", "Period start (YYYY-MM-DD)")
+	transactionsCmd.Flags().String("to", "", "Period end (YYYY-MM-DD)")
+}
@@ -0,0 +1,156 @@
+package portfolio
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	RuleDrift          = "drift"
+	RuleMaxPosition    = "max-position"
+	RuleMaxFreeCash    = "max-free-cash"
+	RuleReconciliation = "reconciliation"
+)
+
+// CheckRules configures CheckPortfolio. Nil limits are not evaluated.
+type CheckRules struct {
+	// MaxDriftBps flags holdings and groups whose weight is further than this from Targets.
+	MaxDriftBps *int
+	Targets     *Targets
+	// MaxPositionPct flags any single holding above this share of holdings value.
+	MaxPositionPct *float64
+	// MaxFreeCash flags free cash above this amount (account currency).
+	MaxFreeCash *float64
+	// FailOnReconcile flags reconciliation warnings.
+	FailOnReconcile bool
+}
+
+// Rules lists the names of the rules that will be evaluated.
+func (r CheckRules) Rules() []string {
+	var out []string
+	if r.MaxDriftBps != nil {
+		out = append(out, RuleDrift)
+	}
+	if r.MaxPositionPct != nil {
+		out = append(out, RuleMaxPosition)
+	}
+	if r.MaxFreeCash != nil {
+		out = append(out, RuleMaxFreeCash)
+	}
+	if r.FailOnReconcile {
+		out = append(out, RuleReconciliation)
+	}
+	return out
+}
+
+type Violation struct {
+	Rule    string  `json:"rule"`
+	Ticker  string  `json:"ticker,omitempty"`
+	Group   string  `json:"group,omitempty"`
+	Value   float64 `json:"value"` // observed value (bps, percent or money, depending on the rule)
+	Limit   float64 `json:"limit"`
+	Message string  `json:"message"`
+}
+
+type CheckOutput struct {
+	SchemaVersion int         `json:"schemaVersion"`
+	Report        Report      `json:"report"`
+	AccountID     int64       `json:"accountId,omitempty"`
+	Currency      string      `json:"currency"`
+	OK            bool        `json:"ok"`
+	Rules         []string    `json:"rules"` // rules that were evaluated
+	Violations    []Violation `json:"violations"`
+}
+
+// CheckPortfolio evaluates rules against a portfolio report.
+func CheckPortfolio(output *Output, rules CheckRules, now time.Time) (*CheckOutput, error) {
+	res := &CheckOutput{
+		SchemaVersion: SchemaVersion,
+		Report:        newReport(now, output.Report.Period),
+		AccountID:     output.Summary.AccountID,
+		Currency:      output.Summary.Currency,
+		Rules:         rules.Rules(),
+		Violations:    []Violation{},
+	}
+
+	if rules.MaxDriftBps != nil {
+		if rules.Targets == nil {
+			return nil, fmt.Errorf("%w: drift rule needs a targets file", ErrInvalidTargets)
+		}
+		plan, err := PlanRebalance(output, *rules.Targets, RebalanceOptions{}, now)
+		if err != nil {
+			return nil, err
+		}
+		limit := *rules.MaxDriftBps
+		for _, row := range plan.Rows {
+			if abs(row.DriftBps) > limit {
+				res.Violations = append(res.Violations, Violation{
+					Rule:    RuleDrift,
+					Ticker:  row.Ticker,
+					Group:   row.Group,
+					Value:   float64(row.DriftBps),
+					Limit:   float64(limit),
+					Message: fmt.Sprintf("%s drifted %+d bps from target (%.2f%% vs %.2f%%)", row.Ticker, row.DriftBps, bpsPct(row.CurrentBps), bpsPct(row.TargetBps)),
+				})
+			}
+		}
+		for _, g := range plan.Groups {
+			if abs(g.DriftBps) > limit {
+				res.Violations = append(res.Violations, Violation{
+					Rule:    RuleDrift,
+					Group:   g.Name,
+					Value:   float64(g.DriftBps),
+					Limit:   float64(limit),
+					Message: fmt.Sprintf("group %s drifted %+d bps from target (%.2f%% vs %.2f%%)", g.Name, g.DriftBps, bpsPct(g.CurrentBps), bpsPct(g.TargetBps)),
+				})
+			}
+		}
+	}
+
+	if rules.MaxPositionPct != nil {
+		limit := *rules.MaxPositionPct
+		for _, h := range output.Holdings {
+			if h.HoldingsPct > limit {
+				res.Violations = append(res.Violations, Violation{
+					Rule:    RuleMaxPosition,
+					Ticker:  h.Ticker,
+					Value:   h.HoldingsPct,
+					Limit:   limit,
+					Message: fmt.Sprintf("%s is %.2f%% of holdings (limit %.2f%%)", h.Ticker, h.HoldingsPct, limit),
+				})
+			}
+		}
+	}
+
+	if rules.MaxFreeCash != nil {
+		limit := *rules.MaxFreeCash
+		if cash := output.Summary.Derived.FreeCash; cash > limit {
+			res.Violations = append(res.Violations, Violation{
+				Rule:    RuleMaxFreeCash,
+				Value:   Round(cash, 2),
+				Limit:   limit,
+				Message: fmt.Sprintf("free cash %.2f %s is above %.2f", cash, output.Summary.Currency, limit),
+			})
+		}
+	}
+
+	if rules.FailOnReconcile {
+		for _, w := range output.Summary.Reconciliation.Warnings {
+			res.Violations = append(res.Violations, Violation{Rule: RuleReconciliation, Message: w})
+		}
+	}
+
+	res.OK = len(res.Violations) == 0
+	return res, nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func bpsPct(bps int) float64 {
+	return float64(bps) / 100
+}
@@ -0,0 +1,264 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// AccountOutput is one profile's portfolio report, as input to Consolidate.
+type AccountOutput struct {
+	Profile string
+	Output  *Output
+}
+
+// Consolidate merges per-account reports into one report in the reporting currency of rates.
+// Holdings are matched by ISIN (ticker when the ISIN is missing) and keep a per-account
+// breakdown. Each account's own derived metrics and reconciliation are kept in Accounts.
+// Flow-based returns (TWR, MWR) are not chained across accounts and stay per account.
+func Consolidate(accounts []AccountOutput, rates *FXRates, now time.Time, period PeriodRange) (*Output, error) {
+	currency := rates.Base()
+	out := &Output{
+		SchemaVersion: SchemaVersion,
+		Report:        newReport(now, period),
+		Summary:       Summary{Currency: currency},
+		Allocation:    []AllocationRow{},
+		Holdings:      []HoldingRow{},
+	}
+
+	type merged struct {
+		row        HoldingRow
+		avgWeight  float64 // sum of avgPricePaid * qty
+		fxComplete bool
+		mixedLines bool // same ISIN listed in different currencies; prices are not comparable
+	}
+	var order []string
+	byKey := make(map[string]*merged)
+
+	d := &out.Summary.Derived
+	fxComplete := true
+	var flowsUsed, mwrUsed bool
+	var flows *PeriodFlows
+
+	for _, acct := range accounts {
+		o := acct.Output
+		if o == nil {
+			continue
+		}
+		rate, source, ok := rates.Rate(o.Summary.Currency)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s -> %s (profile %s)", ErrMissingFXRate, o.Summary.Currency, currency, acct.Profile)
+		}
+		conv := func(v float64) float64 { return v * rate }
+
+		ad := o.Summary.Derived
+		out.Accounts = append(out.Accounts, AccountSection{
+			Profile:        acct.Profile,
+			AccountID:      o.Summary.AccountID,
+			Currency:       o.Summary.Currency,
+			FXRate:         Round(rate, 6),
+			FXSource:       source,
+			AccountTotal:   Round(conv(ad.AccountTotal), 2),
+			HoldingsValue:  Round(conv(ad.HoldingsValue), 2),
+			Derived:        ad,
+			Reconciliation: o.Summary.Reconciliation,
+			Raw:            o.Raw,
+		})
+
+		d.HoldingsValue += conv(ad.HoldingsValue)
+		d.PieCash += conv(ad.PieCash)
+		d.Allocated += conv(ad.Allocated)
+		d.FreeCash += conv(ad.FreeCash)
+		d.AccountTotal += conv(ad.AccountTotal)
+		d.HoldingsCost += conv(ad.HoldingsCost)
+		d.HoldingsPnL += conv(ad.HoldingsPnL)
+		if ad.HoldingsFXImpact != nil {
+			v := conv(*ad.HoldingsFXImpact)
+			if d.HoldingsFXImpact == nil {
+				d.HoldingsFXImpact = new(float64)
+			}
+			*d.HoldingsFXImpact += v
+		} else {
+			fxComplete = false
+		}
+		flowsUsed = flowsUsed || FlowAdjustedTWR(ad.TWRMethod)
+		mwrUsed = mwrUsed || ad.MWRPct != nil || ad.MWRDescription != ""
+
+		snap := &out.Summary.Snapshot
+		snap.APIInvestmentsValue += conv(o.Summary.Snapshot.APIInvestmentsValue)
+		snap.APICashInPies += conv(o.Summary.Snapshot.APICashInPies)
+		snap.APICashAvailable += conv(o.Summary.Snapshot.APICashAvailable)
+		snap.APICashReserved += conv(o.Summary.Snapshot.APICashReserved)
+		snap.APIRealizedPnL += conv(o.Summary.Snapshot.APIRealizedPnL)
+		snap.APITotalCost += conv(o.Summary.Snapshot.APITotalCost)
+		snap.APITotalValue += conv(o.Summary.Snapshot.APITotalValue)
+
+		rec := &out.Summary.Reconciliation
+		rec.AllocatedDiff += conv(o.Summary.Reconciliation.AllocatedDiff)
+		rec.AccountTotalDiff += conv(o.Summary.Reconciliation.AccountTotalDiff)
+		for _, w := range o.Summary.Reconciliation.Warnings {
+			rec.Warnings = append(rec.Warnings, fmt.Sprintf("[%s] %s", acct.Profile, w))
+		}
+
+		if pf := o.PeriodFlows; pf != nil {
+			if flows == nil {
+				flows = &PeriodFlows{Available: true}
+			}
+			flows.Available = flows.Available && pf.Available
+			flows.OrderCount += pf.OrderCount
+			flows.Buys += conv(pf.Buys)
+			flows.Sells += conv(pf.Sells)
+			flows.Fees += conv(pf.Fees)
+			for _, w := range pf.Warnings {
+				flows.Warnings = append(flows.Warnings, fmt.Sprintf("[%s] %s", acct.Profile, w))
+			}
+		}
+
+		for _, h := range o.Holdings {
+			key := h.ISIN
+			if key == "" {
+				key = "ticker:" + h.Ticker
+			}
+			m, ok := byKey[key]
+			if !ok {
+				m = &merged{row: HoldingRow{
+					Ticker:             h.Ticker,
+					Name:               h.Name,
+					ISIN:               h.ISIN,
+					OpenedAt:           h.OpenedAt,
+					InstrumentCurrency: h.InstrumentCurrency,
+					CurrentPrice:       h.CurrentPrice,
+					AccountCurrency:    currency,
+				}, fxComplete: true}
+				byKey[key] = m
+				order = append(order, key)
+			}
+			r := &m.row
+			if h.InstrumentCurrency != r.InstrumentCurrency {
+				m.mixedLines = true
+			}
+			r.Qty += h.Qty
+			r.TradableQty += h.TradableQty
+			r.QtyInPies += h.QtyInPies
+			m.avgWeight += h.AvgPricePaid * h.Qty
+			r.Invested += conv(h.Invested)
+			r.MarketValue += conv(h.MarketValue)
+			r.UnrealizedPnL += conv(h.UnrealizedPnL)
+			if h.FXImpact != nil {
+				v := conv(*h.FXImpact)
+				if r.FXImpact == nil {
+					r.FXImpact = new(float64)
+				}
+				*r.FXImpact += v
+			} else {
+				m.fxComplete = false
+			}
+			if h.OpenedAt != "" && (r.OpenedAt == "" || h.OpenedAt < r.OpenedAt) {
+				r.OpenedAt = h.OpenedAt
+			}
+			if h.MWRPct != nil {
+				r.MWRPct = h.MWRPct
+			}
+			r.Accounts = append(r.Accounts, HoldingAccount{
+				Profile:       acct.Profile,
+				AccountID:     o.Summary.AccountID,
+				Ticker:        h.Ticker,
+				Qty:           h.Qty,
+				Invested:      Round(conv(h.Invested), 2),
+				MarketValue:   Round(conv(h.MarketValue), 2),
+				UnrealizedPnL: Round(conv(h.UnrealizedPnL), 2),
+			})
+		}
+	}
+
+	if !fxComplete {
+		d.HoldingsFXImpact = nil
+	}
+	if d.HoldingsFXImpact != nil {
+		ex := d.HoldingsPnL - *d.HoldingsFXImpact
+		d.HoldingsPnLExclFX = &ex
+	}
+
+	holdingsReturn := CalculateHoldingsReturn(d.HoldingsPnL, d.HoldingsCost)
+	d.HoldingsReturnPct = Round(holdingsReturn, 4)
+	d.HoldingsReturnBps = PctToBps(holdingsReturn)
+	reason := ""
+	if flowsUsed {
+		reason = "not linked across accounts (see accounts[].derived)"
+	}
+	twr := holdingsOnlyTWR(holdingsReturn, reason)
+	d.TWRPctEst = Round(twr.pct, 4)
+	d.TWRBpsEst = PctToBps(twr.pct)
+	d.TWRMethod = twr.method
+	d.TWRDescription = twr.description
+	if mwrUsed {
+		d.MWRDescription = "Money-weighted return is computed per account; see accounts[].derived.mwrPct."
+	}
+
+	// Converted sums carry FX noise; keep money at cent precision.
+	for _, v := range []*float64{&d.HoldingsValue, &d.PieCash, &d.Allocated, &d.FreeCash, &d.AccountTotal, &d.HoldingsCost, &d.HoldingsPnL, d.HoldingsFXImpact, d.HoldingsPnLExclFX} {
+		if v != nil {
+			*v = Round(*v, 2)
+		}
+	}
+	snap := &out.Summary.Snapshot
+	for _, v := range []*float64{&snap.APIInvestmentsValue, &snap.APICashInPies, &snap.APICashAvailable, &snap.APICashReserved, &snap.APIRealizedPnL, &snap.APITotalCost, &snap.APITotalValue} {
+		*v = Round(*v, 2)
+	}
+	out.Summary.Reconciliation.AllocatedDiff = Round(out.Summary.Reconciliation.AllocatedDiff, 2)
+	out.Summary.Reconciliation.AccountTotalDiff = Round(out.Summary.Reconciliation.AccountTotalDiff, 2)
+
+	if flows != nil {
+		flows.Buys = Round(flows.Buys, 2)
+		flows.Sells = Round(flows.Sells, 2)
+		flows.Fees = Round(flows.Fees, 2)
+		flows.Net = Round(flows.Buys-flows.Sells, 2)
+		out.PeriodFlows = flows
+	}
+
+	for _, key := range order {
+		m := byKey[key]
+		r := m.row
+		if r.Qty > 0 {
+			r.AvgPricePaid = m.avgWeight / r.Qty
+		}
+		if m.mixedLines {
+			r.InstrumentCurrency, r.AvgPricePaid, r.CurrentPrice = "", 0, 0
+		}
+		if !m.fxComplete {
+			r.FXImpact = nil
+		}
+		if len(r.Accounts) > 1 {
+			r.MWRPct = nil // returns of different accounts cannot be added up
+		}
+		if r.InstrumentCurrency != "" && normalizeCurrency(r.InstrumentCurrency) != currency {
+			r.FXPair = r.InstrumentCurrency + "/" + currency
+		}
+		pct := CalculateAllocationPercentage(r.MarketValue, d.HoldingsValue)
+		r.HoldingsPct = Round(pct, 2)
+		r.HoldingsBps = PctToBps(pct)
+		r.Invested = Round(r.Invested, 2)
+		r.MarketValue = Round(r.MarketValue, 2)
+		r.UnrealizedPnL = Round(r.UnrealizedPnL, 2)
+		if r.FXImpact != nil {
+			v := Round(*r.FXImpact, 2)
+			r.FXImpact = &v
+		}
+
+		out.Holdings = append(out.Holdings, r)
+		out.Allocation = append(out.Allocation, AllocationRow{
+			Ticker:      r.Ticker,
+			MarketValue: r.MarketValue,
+			HoldingsPct: r.HoldingsPct,
+			HoldingsBps: r.HoldingsBps,
+		})
+	}
+
+	sort.SliceStable(out.Allocation, func(i, j int) bool {
+		return out.Allocation[i].MarketValue > out.Allocation[j].MarketValue
+	})
+	sort.SliceStable(out.Holdings, func(i, j int) bool {
+		return out.Holdings[i].MarketValue > out.Holdings[j].MarketValue
+	})
+	return out, nil
+}
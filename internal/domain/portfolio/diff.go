@@ -0,0 +1,152 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	ChangeNew       = "new"
+	ChangeClosed    = "closed"
+	ChangeChanged   = "changed"
+	ChangeUnchanged = "unchanged"
+)
+
+// DiffSide identifies one side of a comparison (a stored snapshot or live data).
+type DiffSide struct {
+	Label       string `json:"label"`       // snapshot ID or "live"
+	GeneratedAt string `json:"generatedAt"` // RFC3339
+}
+
+// Delta is a before/after pair for a single number.
+type Delta struct {
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+	Delta  float64 `json:"delta"`
+}
+
+type BpsDelta struct {
+	Before int `json:"before"`
+	After  int `json:"after"`
+	Delta  int `json:"delta"`
+}
+
+type MetricsDiff struct {
+	HoldingsValue     Delta    `json:"holdingsValue"`
+	PieCash           Delta    `json:"pieCash"`
+	Allocated         Delta    `json:"allocated"`
+	FreeCash          Delta    `json:"freeCash"`
+	AccountTotal      Delta    `json:"accountTotal"`
+	HoldingsCost      Delta    `json:"holdingsCost"`
+	HoldingsPnL       Delta    `json:"holdingsPnL"`
+	HoldingsReturnBps BpsDelta `json:"holdingsReturnBps"`
+	TWRBpsEst         BpsDelta `json:"twrBpsEst"`
+}
+
+type HoldingChange struct {
+	Ticker string `json:"ticker"`
+	Name   string `json:"name"`
+	ISIN   string `json:"isin,omitempty"`
+	Change string `json:"change"` // new, closed, changed, unchanged
+
+	Qty           Delta    `json:"qty"`
+	MarketValue   Delta    `json:"marketValue"`
+	UnrealizedPnL Delta    `json:"unrealizedPnL"`
+	Weight        BpsDelta `json:"holdingsBps"` // allocation drift in bps
+}
+
+type DiffOutput struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	From          DiffSide        `json:"from"`
+	To            DiffSide        `json:"to"`
+	Currency      string          `json:"currency"`
+	Totals        MetricsDiff     `json:"totals"`
+	Holdings      []HoldingChange `json:"holdings"` // biggest absolute value change first
+	Warnings      []string        `json:"warnings,omitempty"`
+}
+
+// DiffOutputs compares two portfolio reports. Holdings are matched by ticker.
+func DiffOutputs(from, to *Output, fromLabel, toLabel string) *DiffOutput {
+	d := &DiffOutput{
+		SchemaVersion: SchemaVersion,
+		From:          DiffSide{Label: fromLabel, GeneratedAt: from.Report.GeneratedAt},
+		To:            DiffSide{Label: toLabel, GeneratedAt: to.Report.GeneratedAt},
+		Currency:      to.Summary.Currency,
+	}
+	if from.Summary.Currency != to.Summary.Currency {
+		d.Warnings = append(d.Warnings, fmt.Sprintf("currency changed from %s to %s; deltas mix currencies", from.Summary.Currency, to.Summary.Currency))
+	}
+	if from.Summary.AccountID != 0 && to.Summary.AccountID != 0 && from.Summary.AccountID != to.Summary.AccountID {
+		d.Warnings = append(d.Warnings, fmt.Sprintf("comparing different accounts (%d vs %d)", from.Summary.AccountID, to.Summary.AccountID))
+	}
+
+	a, b := from.Summary.Derived, to.Summary.Derived
+	d.Totals = MetricsDiff{
+		HoldingsValue:     newDelta(a.HoldingsValue, b.HoldingsValue),
+		PieCash:           newDelta(a.PieCash, b.PieCash),
+		Allocated:         newDelta(a.Allocated, b.Allocated),
+		FreeCash:          newDelta(a.FreeCash, b.FreeCash),
+		AccountTotal:      newDelta(a.AccountTotal, b.AccountTotal),
+		HoldingsCost:      newDelta(a.HoldingsCost, b.HoldingsCost),
+		HoldingsPnL:       newDelta(a.HoldingsPnL, b.HoldingsPnL),
+		HoldingsReturnBps: newBpsDelta(a.HoldingsReturnBps, b.HoldingsReturnBps),
+		TWRBpsEst:         newBpsDelta(a.TWRBpsEst, b.TWRBpsEst),
+	}
+
+	before := make(map[string]HoldingRow, len(from.Holdings))
+	for _, h := range from.Holdings {
+		before[h.Ticker] = h
+	}
+	seen := make(map[string]bool, len(to.Holdings))
+
+	for _, h := range to.Holdings {
+		seen[h.Ticker] = true
+		prev, ok := before[h.Ticker]
+		change := ChangeNew
+		if ok {
+			change = ChangeUnchanged
+			if prev.Qty != h.Qty || Abs(prev.MarketValue-h.MarketValue) > moneyEpsilon {
+				change = ChangeChanged
+			}
+		}
+		d.Holdings = append(d.Holdings, newHoldingChange(prev, h, change))
+	}
+	for _, h := range from.Holdings {
+		if !seen[h.Ticker] {
+			d.Holdings = append(d.Holdings, newHoldingChange(h, HoldingRow{Ticker: h.Ticker, Name: h.Name, ISIN: h.ISIN}, ChangeClosed))
+		}
+	}
+
+	sort.SliceStable(d.Holdings, func(i, j int) bool {
+		return Abs(d.Holdings[i].MarketValue.Delta) > Abs(d.Holdings[j].MarketValue.Delta)
+	})
+	return d
+}
+
+func newHoldingChange(before, after HoldingRow, change string) HoldingChange {
+	name, isin := after.Name, after.ISIN
+	if name == "" {
+		name = before.Name
+	}
+	if isin == "" {
+		isin = before.ISIN
+	}
+	return HoldingChange{
+		Ticker:        after.Ticker,
+		Name:          name,
+		ISIN:          isin,
+		Change:        change,
+		Qty:           Delta{Before: before.Qty, After: after.Qty, Delta: after.Qty - before.Qty},
+		MarketValue:   newDelta(before.MarketValue, after.MarketValue),
+		UnrealizedPnL: newDelta(before.UnrealizedPnL, after.UnrealizedPnL),
+		Weight:        newBpsDelta(before.HoldingsBps, after.HoldingsBps),
+	}
+}
+
+func newDelta(before, after float64) Delta {
+	return Delta{Before: Round(before, 2), After: Round(after, 2), Delta: Round(after-before, 2)}
+}
+
+func newBpsDelta(before, after int) BpsDelta {
+	return BpsDelta{Before: before, After: after, Delta: after - before}
+}
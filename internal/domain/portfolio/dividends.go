@@ -0,0 +1,224 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+)
+
+// DividendGrouping selects how dividends are aggregated.
+type DividendGrouping string
+
+const (
+	GroupByTicker DividendGrouping = "ticker"
+	GroupByMonth  DividendGrouping = "month"
+	GroupByYear   DividendGrouping = "year"
+)
+
+func ParseDividendGrouping(s string) (DividendGrouping, error) {
+	switch g := DividendGrouping(strings.ToLower(strings.TrimSpace(s))); g {
+	case GroupByTicker, GroupByMonth, GroupByYear:
+		return g, nil
+	case "":
+		return GroupByTicker, nil
+	default:
+		return "", fmt.Errorf("%w %q (expected ticker, month or year)", ErrInvalidGrouping, s)
+	}
+}
+
+type DividendRow struct {
+	Ticker         string  `json:"ticker"`
+	Name           string  `json:"name,omitempty"`
+	ISIN           string  `json:"isin,omitempty"`
+	PaidOn         string  `json:"paidOn"` // RFC3339
+	Type           string  `json:"type"`
+	Qty            float64 `json:"qty"`
+	GrossPerShare  float64 `json:"grossPerShare"` // in ticker currency
+	TickerCurrency string  `json:"tickerCurrency,omitempty"`
+
+	Currency    string   `json:"currency"`              // account currency
+	Net         float64  `json:"net"`                   // amount paid out, account currency
+	Gross       *float64 `json:"gross,omitempty"`       // only when ticker currency == account currency
+	Withholding *float64 `json:"withholding,omitempty"` // gross - net, same condition as gross
+	Reference   string   `json:"reference,omitempty"`
+}
+
+type DividendGroup struct {
+	Key         string   `json:"key"` // ticker, YYYY-MM or YYYY
+	Count       int      `json:"count"`
+	Net         float64  `json:"net"`
+	Gross       *float64 `json:"gross,omitempty"`       // only when known for every dividend in the group
+	Withholding *float64 `json:"withholding,omitempty"` // only when known for every dividend in the group
+}
+
+type DividendsOutput struct {
+	SchemaVersion int              `json:"schemaVersion"`
+	Report        Report           `json:"report"`
+	GroupBy       DividendGrouping `json:"groupBy"`
+	Ticker        string           `json:"ticker,omitempty"`
+	Currency      string           `json:"currency"`
+	Totals        DividendGroup    `json:"totals"`
+	Groups        []DividendGroup  `json:"groups"`
+	Dividends     []DividendRow    `json:"dividends"`
+}
+
+// GetDividends returns paid-out dividends within period, newest first, grouped by groupBy.
+func (s *Service) GetDividends(ctx context.Context, period PeriodRange, ticker string, groupBy DividendGrouping) (*DividendsOutput, error) {
+	rows, err := s.dividendRows(ctx, period, ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := ""
+	if len(rows) > 0 {
+		currency = rows[0].Currency
+	}
+
+	return &DividendsOutput{
+		SchemaVersion: SchemaVersion,
+		Report:        newReport(time.Now(), period),
+		GroupBy:       groupBy,
+		Ticker:        strings.TrimSpace(ticker),
+		Currency:      currency,
+		Totals:        SumDividends("total", rows),
+		Groups:        GroupDividends(rows, groupBy),
+		Dividends:     rows,
+	}, nil
+}
+
+func (s *Service) dividendRows(ctx context.Context, period PeriodRange, ticker string) ([]DividendRow, error) {
+	from, _, _, err := period.Bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.client.GetDividends(ctx, ticker, from)
+	if err != nil {
+		return nil, classifyHistoryError(err)
+	}
+
+	paid := make([]trading212.HistoryDividendItem, 0, len(items))
+	for _, d := range items {
+		if period.Contains(d.PaidOn) {
+			paid = append(paid, d)
+		}
+	}
+	sort.SliceStable(paid, func(i, j int) bool {
+		return paid[i].PaidOn.After(paid[j].PaidOn)
+	})
+
+	rows := make([]DividendRow, 0, len(paid))
+	for _, d := range paid {
+		rows = append(rows, NewDividendRow(d))
+	}
+	return rows, nil
+}
+
+func NewDividendRow(d trading212.HistoryDividendItem) DividendRow {
+	row := DividendRow{
+		Ticker:         d.Ticker,
+		PaidOn:         formatTime(d.PaidOn),
+		Type:           d.Type,
+		Qty:            d.Quantity,
+		GrossPerShare:  d.GrossAmountPerShare,
+		TickerCurrency: d.TickerCurrency,
+		Currency:       d.Currency,
+		Net:            d.Amount,
+		Reference:      d.Reference,
+	}
+	if d.Instrument != nil {
+		row.Name = d.Instrument.Name
+		row.ISIN = d.Instrument.ISIN
+		if row.Ticker == "" {
+			row.Ticker = d.Instrument.Ticker
+		}
+	}
+
+	// Without an FX rate, gross and withholding are only meaningful in a single currency.
+	if d.TickerCurrency != "" && d.TickerCurrency == d.Currency && d.GrossAmountPerShare > 0 {
+		gross := Round(d.GrossAmountPerShare*d.Quantity, 2)
+		withholding := Round(gross-d.Amount, 2)
+		row.Gross = &gross
+		row.Withholding = &withholding
+	}
+	return row
+}
+
+// GroupDividends aggregates rows by ticker (largest net first) or by month/year (chronological).
+func GroupDividends(rows []DividendRow, groupBy DividendGrouping) []DividendGroup {
+	buckets := make(map[string][]DividendRow)
+	var keys []string
+	for _, r := range rows {
+		key := dividendKey(r, groupBy)
+		if _, ok := buckets[key]; !ok {
+			keys = append(keys, key)
+		}
+		buckets[key] = append(buckets[key], r)
+	}
+
+	groups := make([]DividendGroup, 0, len(keys))
+	for _, k := range keys {
+		groups = append(groups, SumDividends(k, buckets[k]))
+	}
+
+	if groupBy == GroupByTicker {
+		sort.SliceStable(groups, func(i, j int) bool {
+			if groups[i].Net != groups[j].Net {
+				return groups[i].Net > groups[j].Net
+			}
+			return groups[i].Key < groups[j].Key
+		})
+	} else {
+		sort.SliceStable(groups, func(i, j int) bool {
+			return groups[i].Key < groups[j].Key
+		})
+	}
+	return groups
+}
+
+// SumDividends totals rows into a single group. Gross and withholding are only set
+// when every row has them, so partial sums are never reported as totals.
+func SumDividends(key string, rows []DividendRow) DividendGroup {
+	g := DividendGroup{Key: key}
+	var gross, withholding float64
+	complete := len(rows) > 0
+	for _, r := range rows {
+		g.Count++
+		g.Net += r.Net
+		if r.Gross == nil || r.Withholding == nil {
+			complete = false
+			continue
+		}
+		gross += *r.Gross
+		withholding += *r.Withholding
+	}
+	g.Net = Round(g.Net, 2)
+	if complete {
+		gross = Round(gross, 2)
+		withholding = Round(withholding, 2)
+		g.Gross = &gross
+		g.Withholding = &withholding
+	}
+	return g
+}
+
+func dividendKey(r DividendRow, groupBy DividendGrouping) string {
+	switch groupBy {
+	case GroupByMonth, GroupByYear:
+		t, err := time.Parse(time.RFC3339, r.PaidOn)
+		if err != nil {
+			return "unknown"
+		}
+		t = t.Local()
+		if groupBy == GroupByYear {
+			return t.Format("2006")
+		}
+		return t.Format("2006-01")
+	default:
+		return r.Ticker
+	}
+}
@@ -5,8 +5,15 @@ import "errors"
 var (
 	ErrMissingAccountDataPermission = errors.New("missing account data permission")
 	ErrMissingPortfolioPermission   = errors.New("missing portfolio permission")
+	ErrMissingHistoryPermission     = errors.New("missing history permission")
 	ErrRateLimited                  = errors.New("rate limited")
 	ErrInvalidPeriod                = errors.New("invalid period")
+	ErrInvalidGrouping              = errors.New("invalid grouping")
+	ErrInvalidFXRate                = errors.New("invalid fx rate")
+	ErrMissingFXRate                = errors.New("missing fx rate")
+	ErrInvalidTargets               = errors.New("invalid targets")
+	ErrInvalidFilter                = errors.New("invalid filter")
+	ErrUnsupportedSchemaVersion     = errors.New("unsupported schema version")
 	ErrConfigNotLoaded              = errors.New("config not loaded")
 	ErrMissingAPIKey                = errors.New("missing api key")
 	ErrMissingAPISecret             = errors.New("missing api secret")
@@ -0,0 +1,150 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	FXSourceSame     = "same"
+	FXSourceExplicit = "explicit"
+	FXSourceImplied  = "implied"
+)
+
+// FXRates converts amounts into a single reporting currency. Explicit rates (e.g. from --fx) win;
+// otherwise a rate is derived from observed currency pairs, chaining through other currencies
+// when there is no direct pair (USD->GBP and USD->EUR give GBP->EUR).
+type FXRates struct {
+	base     string
+	explicit map[string]float64            // reporting-currency units per 1 unit of currency
+	pairs    map[string]map[string]float64 // from -> to -> rate
+}
+
+func NewFXRates(base string) *FXRates {
+	return &FXRates{
+		base:     normalizeCurrency(base),
+		explicit: make(map[string]float64),
+		pairs:    make(map[string]map[string]float64),
+	}
+}
+
+func (r *FXRates) Base() string {
+	return r.base
+}
+
+// Set fixes the rate for currency: 1 unit of currency = rate units of the reporting currency.
+func (r *FXRates) Set(currency string, rate float64) {
+	r.explicit[normalizeCurrency(currency)] = rate
+}
+
+// Observe records an implied rate: 1 unit of from = rate units of to. The first observation of a
+// pair is kept.
+func (r *FXRates) Observe(from, to string, rate float64) {
+	from, to = normalizeCurrency(from), normalizeCurrency(to)
+	if from == "" || to == "" || from == to || rate <= 0 {
+		return
+	}
+	r.addPair(from, to, rate)
+	r.addPair(to, from, 1/rate)
+}
+
+func (r *FXRates) addPair(from, to string, rate float64) {
+	if r.pairs[from] == nil {
+		r.pairs[from] = make(map[string]float64)
+	}
+	if _, ok := r.pairs[from][to]; !ok {
+		r.pairs[from][to] = rate
+	}
+}
+
+// ObserveHoldings derives instrument->account currency rates from position prices and values.
+// GBX (pence) prices are converted to GBP.
+func (r *FXRates) ObserveHoldings(holdings []HoldingRow) {
+	for _, h := range holdings {
+		if h.InstrumentCurrency == "" || h.AccountCurrency == "" || h.Qty <= 0 || h.CurrentPrice <= 0 || h.MarketValue <= 0 {
+			continue
+		}
+		local := h.Qty * h.CurrentPrice
+		if strings.EqualFold(h.InstrumentCurrency, "GBX") {
+			local /= 100
+		}
+		r.Observe(h.InstrumentCurrency, h.AccountCurrency, h.MarketValue/local)
+	}
+}
+
+// Rate returns how many reporting-currency units one unit of currency is worth and where the rate
+// came from.
+func (r *FXRates) Rate(currency string) (rate float64, source string, ok bool) {
+	currency = normalizeCurrency(currency)
+	if currency == r.base {
+		return 1, FXSourceSame, true
+	}
+	if v, ok := r.explicit[currency]; ok {
+		return v, FXSourceExplicit, true
+	}
+
+	// Breadth-first search over observed pairs (shortest chain wins).
+	type node struct {
+		ccy  string
+		rate float64
+	}
+	seen := map[string]bool{currency: true}
+	queue := []node{{currency, 1}}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		next := make([]string, 0, len(r.pairs[n.ccy]))
+		for to := range r.pairs[n.ccy] {
+			next = append(next, to)
+		}
+		sort.Strings(next) // deterministic when several chains have the same length
+		for _, to := range next {
+			if seen[to] {
+				continue
+			}
+			rate := n.rate * r.pairs[n.ccy][to]
+			if to == r.base {
+				return rate, FXSourceImplied, true
+			}
+			if v, ok := r.explicit[to]; ok {
+				return rate * v, FXSourceImplied, true
+			}
+			seen[to] = true
+			queue = append(queue, node{to, rate})
+		}
+	}
+	return 0, "", false
+}
+
+// ParseFXRates parses CCY=RATE pairs (rate in reporting-currency units per 1 CCY).
+func ParseFXRates(specs []string) (map[string]float64, error) {
+	out := make(map[string]float64, len(specs))
+	for _, spec := range specs {
+		for _, part := range strings.Split(spec, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			ccy, value, ok := strings.Cut(part, "=")
+			if !ok {
+				return nil, fmt.Errorf("%w: %q (expected CCY=RATE, e.g. USD=0.92)", ErrInvalidFXRate, part)
+			}
+			rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || rate <= 0 {
+				return nil, fmt.Errorf("%w: %q (rate must be a positive number)", ErrInvalidFXRate, part)
+			}
+			out[normalizeCurrency(ccy)] = rate
+		}
+	}
+	return out, nil
+}
+
+func normalizeCurrency(c string) string {
+	c = strings.ToUpper(strings.TrimSpace(c))
+	if c == "GBX" {
+		return "GBP"
+	}
+	return c
+}
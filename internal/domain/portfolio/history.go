@@ -0,0 +1,150 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HistoryMetric selects the value plotted over time.
+type HistoryMetric string
+
+const (
+	MetricAccountTotal  HistoryMetric = "total"
+	MetricHoldingsValue HistoryMetric = "value"
+	MetricHoldingsCost  HistoryMetric = "cost"
+	MetricHoldingsPnL   HistoryMetric = "pnl"
+	MetricFreeCash      HistoryMetric = "cash"
+	MetricQty           HistoryMetric = "qty" // per-ticker only
+)
+
+// DefaultHistoryMetrics are plotted when no metric is selected.
+var DefaultHistoryMetrics = []HistoryMetric{MetricAccountTotal, MetricHoldingsValue, MetricHoldingsCost}
+
+var historyMetricLabels = map[HistoryMetric]string{
+	MetricAccountTotal:  "account total",
+	MetricHoldingsValue: "holdings value",
+	MetricHoldingsCost:  "cost basis",
+	MetricHoldingsPnL:   "uPnL",
+	MetricFreeCash:      "free cash",
+	MetricQty:           "quantity",
+}
+
+// ParseHistoryMetrics parses a comma-separated metric list. Empty input selects DefaultHistoryMetrics.
+func ParseHistoryMetrics(s string) ([]HistoryMetric, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultHistoryMetrics, nil
+	}
+	var out []HistoryMetric
+	for _, part := range strings.Split(s, ",") {
+		m := HistoryMetric(strings.ToLower(strings.TrimSpace(part)))
+		if _, ok := historyMetricLabels[m]; !ok {
+			return nil, fmt.Errorf("unknown metric %q (expected total, value, cost, pnl, cash or qty)", part)
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// SnapshotPoint is a stored report and the time it was taken.
+type SnapshotPoint struct {
+	At     time.Time
+	Output *Output
+}
+
+type HistoryPoint struct {
+	At    string  `json:"at"` // RFC3339
+	Value float64 `json:"value"`
+}
+
+type HistorySeries struct {
+	Metric HistoryMetric  `json:"metric"`
+	Label  string         `json:"label"`
+	Ticker string         `json:"ticker,omitempty"`
+	Points []HistoryPoint `json:"points"` // oldest first
+}
+
+type HistoryOutput struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Period        PeriodRange     `json:"period"`
+	Currency      string          `json:"currency"`
+	Series        []HistorySeries `json:"series"`
+}
+
+// BuildHistory extracts one series per metric from snapshots within period. With a ticker, values
+// come from that holding (value, cost, pnl, qty); snapshots where it was not held count as zero.
+func BuildHistory(points []SnapshotPoint, period PeriodRange, metrics []HistoryMetric, ticker string) (*HistoryOutput, error) {
+	ticker = strings.TrimSpace(ticker)
+	for _, m := range metrics {
+		if ticker == "" && m == MetricQty {
+			return nil, fmt.Errorf("metric %q requires --ticker", m)
+		}
+		if ticker != "" && (m == MetricAccountTotal || m == MetricFreeCash) {
+			return nil, fmt.Errorf("metric %q is account-level and cannot be combined with --ticker", m)
+		}
+	}
+
+	selected := make([]SnapshotPoint, 0, len(points))
+	for _, p := range points {
+		if p.Output != nil && period.Contains(p.At) {
+			selected = append(selected, p)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].At.Before(selected[j].At)
+	})
+
+	out := &HistoryOutput{SchemaVersion: SchemaVersion, Period: period}
+	if len(selected) > 0 {
+		out.Currency = selected[len(selected)-1].Output.Summary.Currency
+	}
+
+	for _, m := range metrics {
+		series := HistorySeries{Metric: m, Label: historyMetricLabels[m], Ticker: ticker}
+		for _, p := range selected {
+			series.Points = append(series.Points, HistoryPoint{
+				At:    formatTime(p.At),
+				Value: Round(historyValue(p.Output, m, ticker), 6),
+			})
+		}
+		out.Series = append(out.Series, series)
+	}
+	return out, nil
+}
+
+func historyValue(o *Output, m HistoryMetric, ticker string) float64 {
+	if ticker != "" {
+		for _, h := range o.Holdings {
+			if !strings.EqualFold(h.Ticker, ticker) {
+				continue
+			}
+			switch m {
+			case MetricHoldingsValue:
+				return h.MarketValue
+			case MetricHoldingsCost:
+				return h.Invested
+			case MetricHoldingsPnL:
+				return h.UnrealizedPnL
+			case MetricQty:
+				return h.Qty
+			}
+		}
+		return 0
+	}
+
+	d := o.Summary.Derived
+	switch m {
+	case MetricAccountTotal:
+		return d.AccountTotal
+	case MetricHoldingsValue:
+		return d.HoldingsValue
+	case MetricHoldingsCost:
+		return d.HoldingsCost
+	case MetricHoldingsPnL:
+		return d.HoldingsPnL
+	case MetricFreeCash:
+		return d.FreeCash
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package portfolio
+
+import (
+	"context"
+	"time"
+)
+
+const mwrDescription = "Annualized money-weighted return (XIRR) since the first recorded cash flow; " +
+	"reflects the timing and size of deposits/withdrawals (account) or buys, sells and dividends (holdings)."
+
+// holdingFlows returns per-ticker cash flows from all filled orders and paid dividends, using the
+// "positive = money into the holding" convention: buys are positive, sells and dividends negative.
+func (s *Service) holdingFlows(ctx context.Context) (flows map[string][]CashFlow, unavailable string, err error) {
+	orders, err := s.client.GetHistoricalOrders(ctx, "", time.Time{})
+	if err != nil {
+		err = classifyHistoryError(err)
+		if reason, ok := historyUnavailable(err, "order history"); ok {
+			return nil, reason, nil
+		}
+		return nil, "", err
+	}
+	dividends, err := s.client.GetDividends(ctx, "", time.Time{})
+	if err != nil {
+		err = classifyHistoryError(err)
+		if reason, ok := historyUnavailable(err, "dividend history"); ok {
+			return nil, reason, nil
+		}
+		return nil, "", err
+	}
+
+	flows = make(map[string][]CashFlow)
+	for _, o := range orders {
+		if o.Fill == nil {
+			continue
+		}
+		row := NewOrderRow(o)
+		switch row.Side {
+		case SideBuy:
+			flows[row.Ticker] = append(flows[row.Ticker], CashFlow{At: o.Fill.FilledAt, Amount: row.Value})
+		case SideSell:
+			flows[row.Ticker] = append(flows[row.Ticker], CashFlow{At: o.Fill.FilledAt, Amount: -row.Value})
+		}
+	}
+	for _, d := range dividends {
+		row := NewDividendRow(d)
+		flows[row.Ticker] = append(flows[row.Ticker], CashFlow{At: d.PaidOn, Amount: -row.Net})
+	}
+	return flows, "", nil
+}
+
+// applyMWR fills account and per-holding money-weighted returns. accountFlows are deposits and
+// withdrawals; flowsUnavailable explains why they could not be read (empty when they were).
+func (s *Service) applyMWR(ctx context.Context, output *Output, now time.Time, accountFlows []CashFlow, flowsUnavailable string) error {
+	derived := &output.Summary.Derived
+	derived.MWRDescription = mwrDescription
+
+	if flowsUnavailable != "" {
+		derived.MWRDescription += " Account MWR unavailable: " + flowsUnavailable + "."
+	} else if pct, ok := MoneyWeightedReturn(accountFlows, derived.AccountTotal, now); ok {
+		v := Round(pct, 4)
+		derived.MWRPct = &v
+	}
+
+	perTicker, unavailable, err := s.holdingFlows(ctx)
+	if err != nil {
+		return err
+	}
+	if unavailable != "" {
+		derived.MWRDescription += " Holding MWR unavailable: " + unavailable + "."
+		return nil
+	}
+	for i := range output.Holdings {
+		h := &output.Holdings[i]
+		if pct, ok := MoneyWeightedReturn(perTicker[h.Ticker], h.MarketValue, now); ok {
+			v := Round(pct, 4)
+			h.MWRPct = &v
+		}
+	}
+	return nil
+}
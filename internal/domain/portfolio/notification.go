@@ -0,0 +1,102 @@
+package portfolio
+
+import "time"
+
+const (
+	EventSummary    = "summary"
+	EventViolations = "violations"
+)
+
+// Notification is the JSON payload pushed to webhooks and the data passed to body templates.
+type Notification struct {
+	SchemaVersion int                 `json:"schemaVersion"`
+	Event         string              `json:"event"` // summary or violations
+	Report        Report              `json:"report"`
+	AccountID     int64               `json:"accountId,omitempty"`
+	Profile       string              `json:"profile,omitempty"`
+	Currency      string              `json:"currency"`
+	Summary       NotificationSummary `json:"summary"`
+	DailyChange   *DailyChange        `json:"dailyChange,omitempty"` // null without a snapshot from a previous day
+	Violations    []Violation         `json:"violations,omitempty"`
+	TopHoldings   []AllocationRow     `json:"topHoldings"`
+}
+
+type NotificationSummary struct {
+	AccountTotal      float64 `json:"accountTotal"`
+	HoldingsValue     float64 `json:"holdingsValue"`
+	FreeCash          float64 `json:"freeCash"`
+	HoldingsPnL       float64 `json:"holdingsPnL"`
+	HoldingsReturnPct float64 `json:"holdingsReturnPct"`
+	HoldingsCount     int     `json:"holdingsCount"`
+}
+
+// DailyChange compares the report with the latest stored snapshot from before today.
+type DailyChange struct {
+	Since         string  `json:"since"` // RFC3339 of the baseline snapshot
+	AccountTotal  Delta   `json:"accountTotal"`
+	HoldingsValue Delta   `json:"holdingsValue"`
+	HoldingsPnL   Delta   `json:"holdingsPnL"`
+	ChangePct     float64 `json:"changePct"` // account total change in percent
+}
+
+// notificationTopHoldings is how many of the largest holdings a notification carries.
+const notificationTopHoldings = 5
+
+// NewNotification builds a webhook payload. baseline is the previous report (nil when none);
+// check holds rule results (nil when no rules were evaluated).
+func NewNotification(output *Output, baseline *SnapshotPoint, check *CheckOutput, now time.Time) *Notification {
+	d := output.Summary.Derived
+	n := &Notification{
+		SchemaVersion: SchemaVersion,
+		Event:         EventSummary,
+		Report:        newReport(now, output.Report.Period),
+		AccountID:     output.Summary.AccountID,
+		Currency:      output.Summary.Currency,
+		Summary: NotificationSummary{
+			AccountTotal:      Round(d.AccountTotal, 2),
+			HoldingsValue:     Round(d.HoldingsValue, 2),
+			FreeCash:          Round(d.FreeCash, 2),
+			HoldingsPnL:       Round(d.HoldingsPnL, 2),
+			HoldingsReturnPct: Round(d.HoldingsReturnPct, 2),
+			HoldingsCount:     len(output.Holdings),
+		},
+		TopHoldings: output.Allocation[:min(len(output.Allocation), notificationTopHoldings)],
+	}
+
+	if baseline != nil && baseline.Output != nil {
+		b := baseline.Output.Summary.Derived
+		change := DailyChange{
+			Since:         baseline.At.Format(time.RFC3339),
+			AccountTotal:  newDelta(b.AccountTotal, d.AccountTotal),
+			HoldingsValue: newDelta(b.HoldingsValue, d.HoldingsValue),
+			HoldingsPnL:   newDelta(b.HoldingsPnL, d.HoldingsPnL),
+		}
+		if b.AccountTotal > 0 {
+			change.ChangePct = Round((d.AccountTotal-b.AccountTotal)/b.AccountTotal*100, 2)
+		}
+		n.DailyChange = &change
+	}
+
+	if check != nil && !check.OK {
+		n.Event = EventViolations
+		n.Violations = check.Violations
+	}
+	return n
+}
+
+// DailyBaseline returns the latest point from before the local day of now.
+func DailyBaseline(points []SnapshotPoint, now time.Time) *SnapshotPoint {
+	y, m, day := now.Date()
+	startOfDay := time.Date(y, m, day, 0, 0, 0, 0, now.Location())
+	var best *SnapshotPoint
+	for i := range points {
+		p := &points[i]
+		if p.Output == nil || !p.At.Before(startOfDay) {
+			continue
+		}
+		if best == nil || p.At.After(best.At) {
+			best = p
+		}
+	}
+	return best
+}
@@ -0,0 +1,206 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+)
+
+const (
+	SideBuy  = "BUY"
+	SideSell = "SELL"
+)
+
+type OrderRow struct {
+	ID        int64  `json:"id"`
+	FillID    int64  `json:"fillId"`
+	Ticker    string `json:"ticker"`
+	Name      string `json:"name"`
+	ISIN      string `json:"isin,omitempty"`
+	Side      string `json:"side"` // BUY or SELL
+	Type      string `json:"type"` // e.g. MARKET, LIMIT
+	Status    string `json:"status"`
+	CreatedAt string `json:"createdAt"` // RFC3339
+	FilledAt  string `json:"filledAt"`  // RFC3339
+
+	Qty                float64 `json:"qty"`
+	Price              float64 `json:"price"` // fill price in instrument currency
+	InstrumentCurrency string  `json:"instrumentCurrency"`
+
+	AccountCurrency string   `json:"accountCurrency"`
+	Value           float64  `json:"value"` // absolute wallet impact in account currency
+	Fees            float64  `json:"fees"`  // taxes and charges in account currency
+	FXRate          *float64 `json:"fxRate,omitempty"`
+	RealizedPnL     *float64 `json:"realizedPnL,omitempty"`
+}
+
+type OrderTotals struct {
+	Count int     `json:"count"`
+	Buys  float64 `json:"buys"`  // account currency
+	Sells float64 `json:"sells"` // account currency
+	Fees  float64 `json:"fees"`  // account currency
+	Net   float64 `json:"net"`   // buys - sells (net capital moved into holdings)
+}
+
+type OrdersOutput struct {
+	SchemaVersion int         `json:"schemaVersion"`
+	Report        Report      `json:"report"`
+	Ticker        string      `json:"ticker,omitempty"`
+	Currency      string      `json:"currency"`
+	Totals        OrderTotals `json:"totals"`
+	Orders        []OrderRow  `json:"orders"`
+}
+
+// GetOrders returns executed (filled) orders within period, newest first.
+// If ticker is non-empty, only orders for that ticker are returned.
+func (s *Service) GetOrders(ctx context.Context, period PeriodRange, ticker string) (*OrdersOutput, error) {
+	rows, err := s.filledOrders(ctx, period, ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := ""
+	if len(rows) > 0 {
+		currency = rows[0].AccountCurrency
+	}
+
+	return &OrdersOutput{
+		SchemaVersion: SchemaVersion,
+		Report:        newReport(time.Now(), period),
+		Ticker:        strings.TrimSpace(ticker),
+		Currency:      currency,
+		Totals:        SummarizeOrders(rows),
+		Orders:        rows,
+	}, nil
+}
+
+func (s *Service) filledOrders(ctx context.Context, period PeriodRange, ticker string) ([]OrderRow, error) {
+	from, _, _, err := period.Bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.client.GetHistoricalOrders(ctx, ticker, from)
+	if err != nil {
+		return nil, classifyHistoryError(err)
+	}
+
+	filled := make([]trading212.HistoricalOrder, 0, len(orders))
+	for _, o := range orders {
+		if o.Fill == nil || !period.Contains(o.Fill.FilledAt) {
+			continue
+		}
+		filled = append(filled, o)
+	}
+	sort.SliceStable(filled, func(i, j int) bool {
+		return filled[i].Fill.FilledAt.After(filled[j].Fill.FilledAt)
+	})
+
+	rows := make([]OrderRow, 0, len(filled))
+	for _, o := range filled {
+		rows = append(rows, NewOrderRow(o))
+	}
+	return rows, nil
+}
+
+// periodFlows aggregates filled orders within period. Missing permissions and rate limits
+// degrade to an unavailable result with a warning instead of failing the whole report.
+func (s *Service) periodFlows(ctx context.Context, period PeriodRange, accountCurrency string) (*PeriodFlows, error) {
+	rows, err := s.filledOrders(ctx, period, "")
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrMissingHistoryPermission):
+			return &PeriodFlows{
+				Warnings: []string{"period flows unavailable: enable the \"History\" permission for your Trading212 API key"},
+			}, nil
+		case errors.Is(err, ErrRateLimited):
+			return &PeriodFlows{
+				Warnings: []string{"period flows unavailable: order history is rate limited, try again in a minute"},
+			}, nil
+		}
+		return nil, err
+	}
+
+	var warnings []string
+	matched := make([]OrderRow, 0, len(rows))
+	for _, r := range rows {
+		if r.AccountCurrency != "" && accountCurrency != "" && r.AccountCurrency != accountCurrency {
+			warnings = append(warnings, fmt.Sprintf("order %d skipped: wallet currency %s differs from account currency %s", r.ID, r.AccountCurrency, accountCurrency))
+			continue
+		}
+		matched = append(matched, r)
+	}
+
+	t := SummarizeOrders(matched)
+	return &PeriodFlows{
+		Available:  true,
+		OrderCount: t.Count,
+		Buys:       t.Buys,
+		Sells:      t.Sells,
+		Fees:       t.Fees,
+		Net:        t.Net,
+		Warnings:   warnings,
+	}, nil
+}
+
+// NewOrderRow flattens a filled historical order. The order must have a fill.
+func NewOrderRow(o trading212.HistoricalOrder) OrderRow {
+	f := o.Fill
+	ticker := o.Order.Ticker
+	if ticker == "" {
+		ticker = o.Order.Instrument.Ticker
+	}
+
+	return OrderRow{
+		ID:                 o.Order.ID,
+		FillID:             f.ID,
+		Ticker:             ticker,
+		Name:               o.Order.Instrument.Name,
+		ISIN:               o.Order.Instrument.ISIN,
+		Side:               strings.ToUpper(o.Order.Side),
+		Type:               o.Order.Type,
+		Status:             o.Order.Status,
+		CreatedAt:          formatTime(o.Order.CreatedAt),
+		FilledAt:           formatTime(f.FilledAt),
+		Qty:                Abs(f.Quantity),
+		Price:              f.Price,
+		InstrumentCurrency: o.Order.Instrument.Currency,
+		AccountCurrency:    f.WalletImpact.Currency,
+		Value:              Abs(f.WalletImpact.NetValue),
+		Fees:               f.WalletImpact.Fees(),
+		FXRate:             f.WalletImpact.FXRate,
+		RealizedPnL:        f.WalletImpact.RealisedProfitLoss,
+	}
+}
+
+// SummarizeOrders totals buys, sells and fees across rows.
+func SummarizeOrders(rows []OrderRow) OrderTotals {
+	var t OrderTotals
+	for _, r := range rows {
+		t.Count++
+		switch r.Side {
+		case SideBuy:
+			t.Buys += r.Value
+		case SideSell:
+			t.Sells += r.Value
+		}
+		t.Fees += r.Fees
+	}
+	t.Buys = Round(t.Buys, 2)
+	t.Sells = Round(t.Sells, 2)
+	t.Fees = Round(t.Fees, 2)
+	t.Net = Round(t.Buys-t.Sells, 2)
+	return t
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,43 @@
+package portfolio
+
+import (
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// IsAllTime reports whether the period is unbounded.
+func (p PeriodRange) IsAllTime() bool {
+	return p.From == nil && p.To == nil
+}
+
+// Bounds returns the period as a half-open [from, to) interval in local time.
+// The end date is inclusive, so to is midnight of the day after period.To.
+// ok=false for all-time periods.
+func (p PeriodRange) Bounds() (from, to time.Time, ok bool, err error) {
+	if p.From == nil || p.To == nil {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	from, err = time.ParseInLocation(dateLayout, *p.From, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("%w: from: %v", ErrInvalidPeriod, err)
+	}
+	end, err := time.ParseInLocation(dateLayout, *p.To, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("%w: to: %v", ErrInvalidPeriod, err)
+	}
+	if end.Before(from) {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("%w: to is before from", ErrInvalidPeriod)
+	}
+	return from, end.AddDate(0, 0, 1), true, nil
+}
+
+// Contains reports whether t falls inside the period. All-time periods contain everything.
+func (p PeriodRange) Contains(t time.Time) bool {
+	from, to, ok, err := p.Bounds()
+	if err != nil || !ok {
+		return err == nil
+	}
+	return !t.Before(from) && t.Before(to)
+}
@@ -0,0 +1,367 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	RebalanceModeFull    = "full"
+	RebalanceModeBuyOnly = "buy-only"
+
+	ActionBuy  = "buy"
+	ActionSell = "sell"
+	ActionHold = "hold"
+)
+
+// weightTolerancePct is how far target weights may be from 100% in total (rounding in spreadsheets).
+const weightTolerancePct = 0.01
+
+// Target is the desired weight of one instrument, matched by ticker or ISIN.
+type Target struct {
+	Ticker    string
+	ISIN      string
+	WeightPct float64
+	Group     string // set for members of a group
+}
+
+// TargetGroup shares WeightPct between its members. Members with a zero weight split what the
+// weighted members leave equally; member weights are shares of the group (in percent).
+type TargetGroup struct {
+	Name      string
+	WeightPct float64
+	Members   []Target
+}
+
+type Targets struct {
+	Holdings []Target
+	Groups   []TargetGroup
+}
+
+// Flatten resolves groups into per-instrument targets and checks that weights add up to 100%.
+func (t Targets) Flatten() ([]Target, error) {
+	out := make([]Target, 0, len(t.Holdings))
+	seen := make(map[string]bool)
+	add := func(tg Target) error {
+		key := targetKey(tg)
+		if key == "" {
+			return fmt.Errorf("%w: target without ticker or isin", ErrInvalidTargets)
+		}
+		if seen[key] {
+			return fmt.Errorf("%w: %s listed more than once", ErrInvalidTargets, key)
+		}
+		if tg.WeightPct < 0 {
+			return fmt.Errorf("%w: negative weight for %s", ErrInvalidTargets, key)
+		}
+		seen[key] = true
+		out = append(out, tg)
+		return nil
+	}
+
+	for _, tg := range t.Holdings {
+		tg.Group = ""
+		if err := add(tg); err != nil {
+			return nil, err
+		}
+	}
+	for _, g := range t.Groups {
+		if strings.TrimSpace(g.Name) == "" {
+			return nil, fmt.Errorf("%w: group without name", ErrInvalidTargets)
+		}
+		if len(g.Members) == 0 {
+			return nil, fmt.Errorf("%w: group %s has no members", ErrInvalidTargets, g.Name)
+		}
+		var explicit float64
+		unweighted := 0
+		for _, m := range g.Members {
+			explicit += m.WeightPct
+			if m.WeightPct == 0 {
+				unweighted++
+			}
+		}
+		if explicit > 100+weightTolerancePct {
+			return nil, fmt.Errorf("%w: member weights of group %s add up to %.2f%%", ErrInvalidTargets, g.Name, explicit)
+		}
+		if unweighted == 0 && Abs(explicit-100) > weightTolerancePct {
+			return nil, fmt.Errorf("%w: member weights of group %s add up to %.2f%%, expected 100%%", ErrInvalidTargets, g.Name, explicit)
+		}
+		for _, m := range g.Members {
+			share := m.WeightPct
+			if share == 0 {
+				share = (100 - explicit) / float64(unweighted)
+			}
+			m.WeightPct = g.WeightPct * share / 100
+			m.Group = g.Name
+			if err := add(m); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var total float64
+	for _, tg := range out {
+		total += tg.WeightPct
+	}
+	if Abs(total-100) > weightTolerancePct {
+		return nil, fmt.Errorf("%w: weights add up to %.2f%%, expected 100%%", ErrInvalidTargets, total)
+	}
+	return out, nil
+}
+
+func targetKey(t Target) string {
+	if t.ISIN != "" {
+		return "isin:" + strings.ToUpper(t.ISIN)
+	}
+	if t.Ticker != "" {
+		return "ticker:" + t.Ticker
+	}
+	return ""
+}
+
+type RebalanceOptions struct {
+	// BuyOnly never sells; free cash is spread over underweight holdings.
+	BuyOnly bool
+	// MinTrade drops trades smaller than this amount (account currency).
+	MinTrade float64
+	// Cash overrides the free cash available to invest (e.g. to plan an upcoming deposit).
+	Cash *float64
+}
+
+type RebalanceRow struct {
+	Ticker string `json:"ticker"`
+	Name   string `json:"name,omitempty"`
+	ISIN   string `json:"isin,omitempty"`
+	Group  string `json:"group,omitempty"`
+
+	CurrentValue float64 `json:"currentValue"`
+	TargetValue  float64 `json:"targetValue"` // value at target weight after investing the cash
+	CurrentBps   int     `json:"currentBps"`  // AllocationRow.HoldingsBps
+	TargetBps    int     `json:"targetBps"`
+	DriftBps     int     `json:"driftBps"` // current - target
+
+	Action string   `json:"action"`          // buy, sell, hold
+	Amount float64  `json:"amount"`          // absolute trade value (account currency)
+	Price  *float64 `json:"price,omitempty"` // per share, account currency (from current value); null when not held
+	Qty    *float64 `json:"qty,omitempty"`   // shares to trade; null when the price is unknown
+	Note   string   `json:"note,omitempty"`
+}
+
+type RebalanceGroup struct {
+	Name       string  `json:"name"`
+	CurrentBps int     `json:"currentBps"`
+	TargetBps  int     `json:"targetBps"`
+	DriftBps   int     `json:"driftBps"`
+	Buys       float64 `json:"buys"`
+	Sells      float64 `json:"sells"`
+}
+
+type RebalanceTotals struct {
+	Buys     float64 `json:"buys"`
+	Sells    float64 `json:"sells"`
+	CashUsed float64 `json:"cashUsed"` // buys - sells
+	CashLeft float64 `json:"cashLeft"`
+}
+
+type RebalanceOutput struct {
+	SchemaVersion int              `json:"schemaVersion"`
+	Report        Report           `json:"report"`
+	Currency      string           `json:"currency"`
+	Mode          string           `json:"mode"` // full or buy-only
+	MinTrade      float64          `json:"minTrade"`
+	HoldingsValue float64          `json:"holdingsValue"`
+	Cash          float64          `json:"cash"` // cash available to invest
+	Rows          []RebalanceRow   `json:"rows"` // largest trade first
+	Groups        []RebalanceGroup `json:"groups,omitempty"`
+	Totals        RebalanceTotals  `json:"totals"`
+	Warnings      []string         `json:"warnings,omitempty"`
+}
+
+// PlanRebalance compares the portfolio's allocation with targets and proposes trades. In full mode
+// holdings are moved to their target weight of holdings value plus cash; holdings without a target
+// are sold. In buy-only mode only buys are proposed, funded by cash and sized by each holding's
+// shortfall (scaled down when cash is short).
+func PlanRebalance(output *Output, targets Targets, opts RebalanceOptions, now time.Time) (*RebalanceOutput, error) {
+	flat, err := targets.Flatten()
+	if err != nil {
+		return nil, err
+	}
+
+	d := output.Summary.Derived
+	cash := d.FreeCash
+	if opts.Cash != nil {
+		cash = *opts.Cash
+	}
+	if cash < 0 {
+		cash = 0
+	}
+	total := d.HoldingsValue + cash
+
+	res := &RebalanceOutput{
+		SchemaVersion: SchemaVersion,
+		Report:        newReport(now, PeriodRange{}),
+		Currency:      output.Summary.Currency,
+		Mode:          RebalanceModeFull,
+		MinTrade:      opts.MinTrade,
+		HoldingsValue: Round(d.HoldingsValue, 2),
+		Cash:          Round(cash, 2),
+		Rows:          []RebalanceRow{},
+	}
+	if opts.BuyOnly {
+		res.Mode = RebalanceModeBuyOnly
+	}
+
+	bps := make(map[string]int, len(output.Allocation))
+	for _, a := range output.Allocation {
+		bps[a.Ticker] = a.HoldingsBps
+	}
+
+	// Match targets to holdings by ISIN first, then ticker.
+	matched := make(map[int]bool, len(output.Holdings))
+	for _, tg := range flat {
+		row := RebalanceRow{Ticker: tg.Ticker, ISIN: tg.ISIN, Group: tg.Group, TargetBps: PctToBps(tg.WeightPct)}
+		for i, h := range output.Holdings {
+			if matched[i] || !((tg.ISIN != "" && strings.EqualFold(tg.ISIN, h.ISIN)) || (tg.ISIN == "" && tg.Ticker == h.Ticker)) {
+				continue
+			}
+			matched[i] = true
+			row = holdingRebalanceRow(h, bps[h.Ticker], row)
+			break
+		}
+		if row.Ticker == "" {
+			row.Ticker = tg.ISIN
+		}
+		row.TargetValue = tg.WeightPct / 100 * total
+		res.Rows = append(res.Rows, row)
+	}
+	for i, h := range output.Holdings {
+		if matched[i] {
+			continue
+		}
+		row := holdingRebalanceRow(h, bps[h.Ticker], RebalanceRow{Note: "no target"})
+		if opts.BuyOnly {
+			row.TargetValue = row.CurrentValue // never sold in buy-only mode
+		}
+		res.Rows = append(res.Rows, row)
+		res.Warnings = append(res.Warnings, fmt.Sprintf("%s has no target weight", h.Ticker))
+	}
+
+	// Signed trade per row (positive = buy).
+	trades := make([]float64, len(res.Rows))
+	if opts.BuyOnly {
+		var shortfall float64
+		for i, r := range res.Rows {
+			trades[i] = max(r.TargetValue-r.CurrentValue, 0)
+			shortfall += trades[i]
+		}
+		if shortfall > cash && shortfall > 0 {
+			scale := cash / shortfall
+			for i := range trades {
+				trades[i] *= scale
+			}
+		}
+	} else {
+		for i, r := range res.Rows {
+			trades[i] = r.TargetValue - r.CurrentValue
+		}
+	}
+
+	var dropped int
+	for i := range res.Rows {
+		r := &res.Rows[i]
+		r.DriftBps = r.CurrentBps - r.TargetBps
+		amount := Round(trades[i], 2)
+		if Abs(amount) < moneyEpsilon || Abs(amount) < opts.MinTrade {
+			if Abs(amount) >= moneyEpsilon {
+				dropped++
+			}
+			amount = 0
+		}
+
+		switch {
+		case amount > 0:
+			r.Action = ActionBuy
+			res.Totals.Buys += amount
+		case amount < 0:
+			r.Action = ActionSell
+			res.Totals.Sells -= amount
+		default:
+			r.Action = ActionHold
+		}
+		r.Amount = Abs(amount)
+		if r.Price != nil && r.Amount > 0 {
+			q := Round(r.Amount / *r.Price, 6)
+			r.Qty = &q
+		} else if r.Price == nil && r.Amount > 0 {
+			r.Note = "not held; price unknown"
+		}
+		r.CurrentValue = Round(r.CurrentValue, 2)
+		r.TargetValue = Round(r.TargetValue, 2)
+	}
+	if dropped > 0 {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("%d trade(s) below the minimum trade size of %.2f skipped", dropped, opts.MinTrade))
+	}
+
+	res.Totals.Buys = Round(res.Totals.Buys, 2)
+	res.Totals.Sells = Round(res.Totals.Sells, 2)
+	res.Totals.CashUsed = Round(res.Totals.Buys-res.Totals.Sells, 2)
+	res.Totals.CashLeft = Round(cash-res.Totals.CashUsed, 2)
+	if res.Totals.CashLeft < -moneyEpsilon {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("plan needs %.2f more cash than available", -res.Totals.CashLeft))
+	}
+
+	res.Groups = rebalanceGroups(res.Rows)
+
+	sort.SliceStable(res.Rows, func(i, j int) bool {
+		if res.Rows[i].Amount != res.Rows[j].Amount {
+			return res.Rows[i].Amount > res.Rows[j].Amount
+		}
+		return Abs(float64(res.Rows[i].DriftBps)) > Abs(float64(res.Rows[j].DriftBps))
+	})
+	return res, nil
+}
+
+func holdingRebalanceRow(h HoldingRow, currentBps int, row RebalanceRow) RebalanceRow {
+	row.Ticker = h.Ticker
+	row.Name = h.Name
+	row.ISIN = h.ISIN
+	row.CurrentValue = h.MarketValue
+	row.CurrentBps = currentBps
+	if h.Qty > 0 && h.MarketValue > 0 {
+		p := Round(h.MarketValue/h.Qty, 6)
+		row.Price = &p
+	}
+	return row
+}
+
+func rebalanceGroups(rows []RebalanceRow) []RebalanceGroup {
+	var out []RebalanceGroup
+	index := make(map[string]int)
+	for _, r := range rows {
+		if r.Group == "" {
+			continue
+		}
+		i, ok := index[r.Group]
+		if !ok {
+			i = len(out)
+			index[r.Group] = i
+			out = append(out, RebalanceGroup{Name: r.Group})
+		}
+		g := &out[i]
+		g.CurrentBps += r.CurrentBps
+		g.TargetBps += r.TargetBps
+		switch r.Action {
+		case ActionBuy:
+			g.Buys += r.Amount
+		case ActionSell:
+			g.Sells += r.Amount
+		}
+	}
+	for i := range out {
+		out[i].DriftBps = out[i].CurrentBps - out[i].TargetBps
+		out[i].Buys = Round(out[i].Buys, 2)
+		out[i].Sells = Round(out[i].Sells, 2)
+	}
+	return out
+}
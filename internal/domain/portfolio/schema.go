@@ -0,0 +1,80 @@
+package portfolio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/shared/jsonschema"
+)
+
+// schemaIDFormat is the $id of the published schema for a version.
+const schemaIDFormat = "https://github.com/nezdemkovski/folio212/schema/portfolio.v%d.json"
+
+// OutputSchema returns the JSON Schema of the report written by 'portfolio --json' for a schema
+// version, generated from that version's types. Optional values (FX impact, MWR, raw payloads)
+// are nullable.
+func OutputSchema(version int) (*jsonschema.Schema, error) {
+	v, err := lookupOutputVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	s := jsonschema.Generate(v.model)
+	s.ID = fmt.Sprintf(schemaIDFormat, version)
+	s.Title = "folio212 portfolio report"
+	s.Description = fmt.Sprintf("Output of 'folio212 portfolio --json', schema version %d. Money is in summary.currency.", version)
+	if notice := DeprecationNotice(version); notice != "" {
+		s.Description += " Deprecated: " + notice + "."
+	}
+	if p, ok := s.Properties.Lookup("schemaVersion"); ok {
+		p.Const = version
+	}
+	addHoldingProjection(s)
+	return s, nil
+}
+
+// addHoldingProjection also accepts the holdings written by 'portfolio --json --fields': objects
+// with only the selected keys, where unavailable values are null.
+func addHoldingProjection(s *jsonschema.Schema) {
+	holdings, ok := s.Properties.Lookup("holdings")
+	if !ok || holdings.Items == nil || holdings.Items.Ref == "" {
+		return
+	}
+	name := strings.TrimPrefix(holdings.Items.Ref, "#/$defs/")
+	row, ok := s.Defs[name]
+	if !ok {
+		return
+	}
+	projection := jsonschema.Projection(row)
+	projection.Description = "A holding with only the keys selected by --fields; unavailable values are null."
+	s.Defs[name+"Fields"] = projection
+	holdings.Items = &jsonschema.Schema{AnyOf: []*jsonschema.Schema{
+		holdings.Items,
+		{Ref: "#/$defs/" + name + "Fields"},
+	}}
+}
+
+// ValidateOutput checks a 'portfolio --json' document against the schema of the version it
+// declares in schemaVersion.
+func ValidateOutput(data []byte) error {
+	var head struct {
+		SchemaVersion *int `json:"schemaVersion"`
+	}
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&head); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if head.SchemaVersion == nil {
+		return fmt.Errorf("missing schemaVersion")
+	}
+	return ValidateOutputVersion(data, *head.SchemaVersion)
+}
+
+// ValidateOutputVersion checks a document against the schema of a given version.
+func ValidateOutputVersion(data []byte, version int) error {
+	s, err := OutputSchema(version)
+	if err != nil {
+		return err
+	}
+	return jsonschema.ValidateJSON(s, data)
+}
@@ -0,0 +1,172 @@
+package portfolio
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// HoldingFilterFields lists the fields usable in filter expressions.
+var HoldingFilterFields = []string{"ticker", "name", "isin", "currency", "value", "pnl", "return", "pct", "qty", "invested"}
+
+var filterOps = []string{"<=", ">=", "!=", "=", "<", ">"} // two-character operators first
+
+// HoldingFilter is one parsed filter expression such as pnl<0 or currency=USD.
+type HoldingFilter struct {
+	Field string
+	Op    string
+	Value string
+	num   float64
+}
+
+// ParseHoldingFilter parses FIELD OP VALUE. Text fields (ticker, name, isin, currency) support
+// = and != (case-insensitive); numeric fields support =, !=, <, <=, > and >=.
+func ParseHoldingFilter(expr string) (HoldingFilter, error) {
+	e := strings.TrimSpace(expr)
+	for i := range e {
+		for _, op := range filterOps {
+			if !strings.HasPrefix(e[i:], op) {
+				continue
+			}
+			f := HoldingFilter{
+				Field: strings.ToLower(strings.TrimSpace(e[:i])),
+				Op:    op,
+				Value: strings.TrimSpace(e[i+len(op):]),
+			}
+			return f, f.validate(expr)
+		}
+	}
+	return HoldingFilter{}, fmt.Errorf("%w: %q (expected FIELD OP VALUE, e.g. pnl<0)", ErrInvalidFilter, expr)
+}
+
+func (f *HoldingFilter) validate(expr string) error {
+	if !slices.Contains(HoldingFilterFields, f.Field) {
+		return fmt.Errorf("%w: %q: unknown field %q (expected %s)", ErrInvalidFilter, expr, f.Field, strings.Join(HoldingFilterFields, ", "))
+	}
+	if f.Value == "" {
+		return fmt.Errorf("%w: %q: missing value", ErrInvalidFilter, expr)
+	}
+	if isTextFilterField(f.Field) {
+		if f.Op != "=" && f.Op != "!=" {
+			return fmt.Errorf("%w: %q: %s only supports = and !=", ErrInvalidFilter, expr, f.Field)
+		}
+		return nil
+	}
+	n, err := strconv.ParseFloat(f.Value, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q: %s needs a number", ErrInvalidFilter, expr, f.Field)
+	}
+	f.num = n
+	return nil
+}
+
+func isTextFilterField(field string) bool {
+	switch field {
+	case "ticker", "name", "isin", "currency":
+		return true
+	}
+	return false
+}
+
+// Match reports whether h passes the filter.
+func (f HoldingFilter) Match(h HoldingRow) bool {
+	if isTextFilterField(f.Field) {
+		var v string
+		switch f.Field {
+		case "ticker":
+			v = h.Ticker
+		case "name":
+			v = h.Name
+		case "isin":
+			v = h.ISIN
+		case "currency":
+			v = h.InstrumentCurrency
+		}
+		eq := strings.EqualFold(v, f.Value)
+		return eq == (f.Op == "=")
+	}
+
+	var v float64
+	switch f.Field {
+	case "value":
+		v = h.MarketValue
+	case "pnl":
+		v = h.UnrealizedPnL
+	case "return":
+		v = HoldingReturnPct(h)
+	case "pct":
+		v = h.HoldingsPct
+	case "qty":
+		v = h.Qty
+	case "invested":
+		v = h.Invested
+	}
+	switch f.Op {
+	case "=":
+		return v == f.num
+	case "!=":
+		return v != f.num
+	case "<":
+		return v < f.num
+	case "<=":
+		return v <= f.num
+	case ">":
+		return v > f.num
+	default:
+		return v >= f.num
+	}
+}
+
+// HoldingSelection narrows and orders the holdings of a report. Filters are combined with AND;
+// Top = 0 keeps every match.
+type HoldingSelection struct {
+	Sort    HoldingSortKey
+	Reverse bool
+	Filters []HoldingFilter
+	Top     int
+}
+
+// IsZero reports whether the selection leaves the report unchanged.
+func (s HoldingSelection) IsZero() bool {
+	return (s.Sort == "" || s.Sort == SortByValue) && !s.Reverse && len(s.Filters) == 0 && s.Top == 0
+}
+
+// Apply filters, sorts and truncates output.Holdings in place, and keeps output.Allocation to the
+// same holdings in the same order. Summary totals still describe the whole account; weights stay
+// relative to all holdings.
+func (s HoldingSelection) Apply(output *Output) {
+	if s.IsZero() {
+		return
+	}
+	rows := make([]HoldingRow, 0, len(output.Holdings))
+	for _, h := range output.Holdings {
+		keep := true
+		for _, f := range s.Filters {
+			if !f.Match(h) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			rows = append(rows, h)
+		}
+	}
+	SortHoldings(rows, s.Sort, s.Reverse)
+	if s.Top > 0 && len(rows) > s.Top {
+		rows = rows[:s.Top]
+	}
+	output.Holdings = rows
+
+	byTicker := make(map[string]AllocationRow, len(output.Allocation))
+	for _, a := range output.Allocation {
+		byTicker[a.Ticker] = a
+	}
+	allocation := make([]AllocationRow, 0, len(rows))
+	for _, h := range rows {
+		if a, ok := byTicker[h.Ticker]; ok {
+			allocation = append(allocation, a)
+		}
+	}
+	output.Allocation = allocation
+}
@@ -0,0 +1,150 @@
+package portfolio
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseHoldingFilter(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    HoldingFilter
+		wantErr bool
+	}{
+		{expr: "pnl<0", want: HoldingFilter{Field: "pnl", Op: "<", Value: "0", num: 0}},
+		{expr: "pnl<=0", want: HoldingFilter{Field: "pnl", Op: "<=", Value: "0", num: 0}},
+		{expr: " Value >= 1000.5 ", want: HoldingFilter{Field: "value", Op: ">=", Value: "1000.5", num: 1000.5}},
+		{expr: "return>-5", want: HoldingFilter{Field: "return", Op: ">", Value: "-5", num: -5}},
+		{expr: "qty!=3", want: HoldingFilter{Field: "qty", Op: "!=", Value: "3", num: 3}},
+		{expr: "currency=usd", want: HoldingFilter{Field: "currency", Op: "=", Value: "usd"}},
+		{expr: "name!=Apple Inc", want: HoldingFilter{Field: "name", Op: "!=", Value: "Apple Inc"}},
+		{expr: "pnl", wantErr: true},
+		{expr: "=5", wantErr: true},
+		{expr: "price>5", wantErr: true},
+		{expr: "pnl<", wantErr: true},
+		{expr: "pnl<abc", wantErr: true},
+		{expr: "ticker<AAPL", wantErr: true},
+		{expr: "pnl=<0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseHoldingFilter(tt.expr)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidFilter) {
+					t.Errorf("err = %v, want ErrInvalidFilter", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoldingFilterMatch(t *testing.T) {
+	h := HoldingRow{
+		Ticker: "AAPL_US_EQ", Name: "Apple", ISIN: "US0378331005", InstrumentCurrency: "USD",
+		Qty: 3, Invested: 500, MarketValue: 600, UnrealizedPnL: 100, HoldingsPct: 60,
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"ticker=aapl_us_eq", true},
+		{"ticker!=AAPL_US_EQ", false},
+		{"name=apple", true},
+		{"isin=US0378331005", true},
+		{"currency!=EUR", true},
+		{"value=600", true},
+		{"value!=600", false},
+		{"pnl<100", false},
+		{"pnl<=100", true},
+		{"pnl>100", false},
+		{"pnl>=100", true},
+		{"return=20", true},
+		{"pct>50", true},
+		{"qty<3", false},
+		{"invested<=499.99", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := ParseHoldingFilter(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Match(h); got != tt.want {
+				t.Errorf("Match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoldingSelectionApply(t *testing.T) {
+	newOutput := func() *Output {
+		o := &Output{Holdings: []HoldingRow{
+			{Ticker: "AAPL", InstrumentCurrency: "USD", MarketValue: 300, UnrealizedPnL: -20},
+			{Ticker: "MSFT", InstrumentCurrency: "USD", MarketValue: 200, UnrealizedPnL: 50},
+			{Ticker: "VWCE", InstrumentCurrency: "EUR", MarketValue: 400, UnrealizedPnL: 10},
+			{Ticker: "NVDA", InstrumentCurrency: "USD", MarketValue: 100, UnrealizedPnL: 30},
+		}}
+		for _, h := range o.Holdings {
+			o.Allocation = append(o.Allocation, AllocationRow{Ticker: h.Ticker, MarketValue: h.MarketValue})
+		}
+		return o
+	}
+	filter := func(expr string) HoldingFilter {
+		f, err := ParseHoldingFilter(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	tests := []struct {
+		name string
+		sel  HoldingSelection
+		want []string
+	}{
+		{name: "zero selection keeps the report order", want: []string{"AAPL", "MSFT", "VWCE", "NVDA"}},
+		{name: "sort", sel: HoldingSelection{Sort: SortByPnL}, want: []string{"MSFT", "NVDA", "VWCE", "AAPL"}},
+		{name: "reverse", sel: HoldingSelection{Sort: SortByTicker, Reverse: true}, want: []string{"VWCE", "NVDA", "MSFT", "AAPL"}},
+		{
+			name: "filters are combined",
+			sel:  HoldingSelection{Filters: []HoldingFilter{filter("currency=usd"), filter("pnl>0")}},
+			want: []string{"MSFT", "NVDA"},
+		},
+		{
+			name: "filter, sort and top",
+			sel:  HoldingSelection{Sort: SortByValue, Filters: []HoldingFilter{filter("currency=USD")}, Top: 2},
+			want: []string{"AAPL", "MSFT"},
+		},
+		{name: "top larger than the report", sel: HoldingSelection{Top: 10}, want: []string{"VWCE", "AAPL", "MSFT", "NVDA"}},
+		{name: "no match", sel: HoldingSelection{Filters: []HoldingFilter{filter("value>1000")}}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOutput()
+			tt.sel.Apply(o)
+
+			holdings := make([]string, 0, len(o.Holdings))
+			for _, h := range o.Holdings {
+				holdings = append(holdings, h.Ticker)
+			}
+			allocation := make([]string, 0, len(o.Allocation))
+			for _, a := range o.Allocation {
+				allocation = append(allocation, a.Ticker)
+			}
+			if !slices.Equal(holdings, tt.want) {
+				t.Errorf("holdings = %v, want %v", holdings, tt.want)
+			}
+			if !slices.Equal(allocation, holdings) {
+				t.Errorf("allocation = %v, want it aligned with holdings %v", allocation, holdings)
+			}
+		})
+	}
+}
@@ -11,14 +11,41 @@ import (
 )
 
 type Service struct {
-	client *trading212.Client
+	client     *trading212.Client
+	valuations ValuationSource
 }
 
-func NewService(client *trading212.Client) *Service {
-	return &Service{client: client}
+type Option func(*Service)
+
+// WithValuations supplies stored account valuations used as TWR chaining points.
+func WithValuations(src ValuationSource) Option {
+	return func(s *Service) {
+		s.valuations = src
+	}
 }
 
-func (s *Service) GetPortfolio(ctx context.Context, period PeriodRange, includeRaw bool) (*Output, error) {
+func NewService(client *trading212.Client, opts ...Option) *Service {
+	s := &Service{client: client}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	return s
+}
+
+// PortfolioOptions controls optional (slower) parts of GetPortfolio.
+type PortfolioOptions struct {
+	// IncludeRaw embeds raw API payloads in the output.
+	IncludeRaw bool
+	// WithFlows reads deposit/withdrawal history (History permission) for a flow-adjusted TWR.
+	WithFlows bool
+	// WithMWR reads deposit/withdrawal, order and dividend history (History permission) for
+	// account and per-holding money-weighted returns.
+	WithMWR bool
+}
+
+func (s *Service) GetPortfolio(ctx context.Context, period PeriodRange, opts PortfolioOptions) (*Output, error) {
 	summary, err := s.client.GetAccountSummary(ctx)
 	if err != nil {
 		return nil, classifyAccountError(err)
@@ -41,7 +68,23 @@ func (s *Service) GetPortfolio(ctx context.Context, period PeriodRange, includeR
 	freeCash := summary.Cash.AvailableToTrade + summary.Cash.ReservedForOrders
 
 	holdingsReturn := CalculateHoldingsReturn(holdingsPnL, holdingsCost)
-	twrPct := holdingsReturn // TWR approximation
+	var accountFlows []CashFlow
+	flowsUnavailable := ""
+	if opts.WithFlows || opts.WithMWR {
+		accountFlows, flowsUnavailable, err = s.accountFlows(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	twr := holdingsOnlyTWR(holdingsReturn, "")
+	if opts.WithFlows {
+		if flowsUnavailable != "" {
+			twr = holdingsOnlyTWR(holdingsReturn, flowsUnavailable)
+		} else if twr, err = s.flowTWR(ctx, period, now, summary.ID, summary.TotalValue, holdingsReturn, accountFlows); err != nil {
+			return nil, err
+		}
+	}
 
 	var holdingsFXImpact *float64
 	var holdingsPnLExclFX *float64
@@ -72,16 +115,11 @@ func (s *Service) GetPortfolio(ctx context.Context, period PeriodRange, includeR
 			HoldingsBps: PctToBps(pct),
 		})
 
-		opened := ""
-		if !p.CreatedAt.IsZero() {
-			opened = p.CreatedAt.Format(time.RFC3339)
-		}
-
 		holdings = append(holdings, HoldingRow{
 			Ticker:             p.Instrument.Ticker,
 			Name:               p.Instrument.Name,
 			ISIN:               p.Instrument.ISIN,
-			OpenedAt:           opened,
+			OpenedAt:           formatTime(p.CreatedAt),
 			Qty:                p.Quantity,
 			TradableQty:        p.QuantityAvailableForTrading,
 			QtyInPies:          p.QuantityInPies,
@@ -108,13 +146,10 @@ func (s *Service) GetPortfolio(ctx context.Context, period PeriodRange, includeR
 
 	output := &Output{
 		SchemaVersion: SchemaVersion,
-		Report: Report{
-			ReportDate:  now.Format("2006-01-02"),
-			GeneratedAt: now.Format(time.RFC3339),
-			Period:      period,
-		},
+		Report:        newReport(now, period),
 		Summary: Summary{
-			Currency: summary.Currency,
+			AccountID: summary.ID,
+			Currency:  summary.Currency,
 			Derived: DerivedMetrics{
 				HoldingsValue:     holdingsValue,
 				PieCash:           pieCash,
@@ -127,10 +162,10 @@ func (s *Service) GetPortfolio(ctx context.Context, period PeriodRange, includeR
 				HoldingsPnLExclFX: holdingsPnLExclFX,
 				HoldingsReturnPct: Round(holdingsReturn, 4),
 				HoldingsReturnBps: PctToBps(holdingsReturn),
-				TWRPctEst:         Round(twrPct, 4),
-				TWRBpsEst:         PctToBps(twrPct),
-				TWRMethod:         "holdings-only-no-flows",
-				TWRDescription:    "Estimated TWR based on holdings only; excludes cash flows and pie allocations.",
+				TWRPctEst:         Round(twr.pct, 4),
+				TWRBpsEst:         PctToBps(twr.pct),
+				TWRMethod:         twr.method,
+				TWRDescription:    twr.description,
 			},
 			Snapshot: APISnapshot{
 				APIInvestmentsValue: summary.Investments.CurrentValue,
@@ -147,7 +182,21 @@ func (s *Service) GetPortfolio(ctx context.Context, period PeriodRange, includeR
 		Holdings:   holdings,
 	}
 
-	if includeRaw {
+	if opts.WithMWR {
+		if err := s.applyMWR(ctx, output, now, accountFlows, flowsUnavailable); err != nil {
+			return nil, err
+		}
+	}
+
+	if !period.IsAllTime() {
+		flows, err := s.periodFlows(ctx, period, summary.Currency)
+		if err != nil {
+			return nil, err
+		}
+		output.PeriodFlows = flows
+	}
+
+	if opts.IncludeRaw {
 		output.Raw = &RawData{
 			AccountSummary: summary,
 			Positions:      positions,
@@ -157,6 +206,14 @@ func (s *Service) GetPortfolio(ctx context.Context, period PeriodRange, includeR
 	return output, nil
 }
 
+func newReport(now time.Time, period PeriodRange) Report {
+	return Report{
+		ReportDate:  now.Format(dateLayout),
+		GeneratedAt: now.Format(time.RFC3339),
+		Period:      period,
+	}
+}
+
 // moneyEpsilon is the smallest difference we consider significant for financial calculations.
 const moneyEpsilon = 0.01
 
@@ -205,3 +262,16 @@ func classifyPortfolioError(err error) error {
 	}
 	return err
 }
+
+func classifyHistoryError(err error) error {
+	var httpErr *trading212.HTTPError
+	if errors.As(err, &httpErr) {
+		if httpErr.StatusCode == 403 {
+			return fmt.Errorf("%w: %v", ErrMissingHistoryPermission, err)
+		}
+		if httpErr.StatusCode == 429 {
+			return fmt.Errorf("%w: %v", ErrRateLimited, err)
+		}
+	}
+	return err
+}
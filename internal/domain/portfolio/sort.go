@@ -0,0 +1,64 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HoldingSortKey selects the ordering of holdings.
+type HoldingSortKey string
+
+const (
+	SortByValue  HoldingSortKey = "value"
+	SortByPnL    HoldingSortKey = "pnl"
+	SortByReturn HoldingSortKey = "return"
+	SortByTicker HoldingSortKey = "ticker"
+	SortByOpened HoldingSortKey = "opened"
+)
+
+// HoldingSortKeys lists every sort key in display order.
+var HoldingSortKeys = []HoldingSortKey{SortByValue, SortByPnL, SortByReturn, SortByTicker, SortByOpened}
+
+func ParseHoldingSortKey(s string) (HoldingSortKey, error) {
+	k := HoldingSortKey(strings.ToLower(strings.TrimSpace(s)))
+	if k == "" {
+		return SortByValue, nil
+	}
+	for _, known := range HoldingSortKeys {
+		if k == known {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown sort key %q (expected value, pnl, return, ticker or opened)", s)
+}
+
+// HoldingReturnPct is the unrealized return of a single holding relative to its cost basis.
+func HoldingReturnPct(h HoldingRow) float64 {
+	return CalculateHoldingsReturn(h.UnrealizedPnL, h.Invested)
+}
+
+// SortHoldings orders rows in place. Numeric keys sort descending (largest first), ticker
+// ascending and opened oldest first; reverse flips the natural order. Ties keep their order.
+func SortHoldings(rows []HoldingRow, key HoldingSortKey, reverse bool) {
+	less := func(a, b HoldingRow) bool {
+		switch key {
+		case SortByPnL:
+			return a.UnrealizedPnL > b.UnrealizedPnL
+		case SortByReturn:
+			return HoldingReturnPct(a) > HoldingReturnPct(b)
+		case SortByTicker:
+			return a.Ticker < b.Ticker
+		case SortByOpened:
+			return a.OpenedAt < b.OpenedAt
+		default:
+			return a.MarketValue > b.MarketValue
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if reverse {
+			return less(rows[j], rows[i])
+		}
+		return less(rows[i], rows[j])
+	})
+}
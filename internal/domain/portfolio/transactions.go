@@ -0,0 +1,187 @@
+package portfolio
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+)
+
+// TransactionType is the normalized category of a cash transaction.
+type TransactionType string
+
+const (
+	TxDeposit    TransactionType = "deposit"
+	TxWithdrawal TransactionType = "withdrawal"
+	TxInterest   TransactionType = "interest"
+	TxFee        TransactionType = "fee"
+	TxTransfer   TransactionType = "transfer"
+	TxOther      TransactionType = "other"
+)
+
+// transactionTypeOrder is the stable display order for per-type totals.
+var transactionTypeOrder = []TransactionType{TxDeposit, TxWithdrawal, TxInterest, TxFee, TxTransfer, TxOther}
+
+type TransactionRow struct {
+	DateTime  string          `json:"dateTime"` // RFC3339
+	Type      TransactionType `json:"type"`
+	RawType   string          `json:"rawType"`  // type as reported by Trading212
+	Amount    float64         `json:"amount"`   // signed cash impact; positive = money into the account
+	Currency  string          `json:"currency"` // account currency
+	Reference string          `json:"reference,omitempty"`
+}
+
+type TransactionTypeTotal struct {
+	Type   TransactionType `json:"type"`
+	Count  int             `json:"count"`
+	Amount float64         `json:"amount"` // signed, same convention as TransactionRow.Amount
+}
+
+type TransactionTotals struct {
+	Deposits    float64                `json:"deposits"`    // money in (positive)
+	Withdrawals float64                `json:"withdrawals"` // money out (positive)
+	NetDeposits float64                `json:"netDeposits"` // deposits - withdrawals
+	Interest    float64                `json:"interest"`
+	Fees        float64                `json:"fees"` // positive
+	ByType      []TransactionTypeTotal `json:"byType"`
+}
+
+type TransactionsOutput struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	Report        Report            `json:"report"`
+	Currency      string            `json:"currency"`
+	Totals        TransactionTotals `json:"totals"`
+	Transactions  []TransactionRow  `json:"transactions"`
+}
+
+// GetTransactions returns cash transactions within period, newest first, with per-type totals.
+func (s *Service) GetTransactions(ctx context.Context, period PeriodRange) (*TransactionsOutput, error) {
+	rows, err := s.transactionRows(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := ""
+	if len(rows) > 0 {
+		currency = rows[0].Currency
+	}
+
+	return &TransactionsOutput{
+		SchemaVersion: SchemaVersion,
+		Report:        newReport(time.Now(), period),
+		Currency:      currency,
+		Totals:        SummarizeTransactions(rows),
+		Transactions:  rows,
+	}, nil
+}
+
+func (s *Service) transactionRows(ctx context.Context, period PeriodRange) ([]TransactionRow, error) {
+	from, _, _, err := period.Bounds()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.client.GetTransactions(ctx, from)
+	if err != nil {
+		return nil, classifyHistoryError(err)
+	}
+
+	matched := make([]trading212.HistoryTransactionItem, 0, len(items))
+	for _, t := range items {
+		if period.Contains(t.DateTime) {
+			matched = append(matched, t)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].DateTime.After(matched[j].DateTime)
+	})
+
+	rows := make([]TransactionRow, 0, len(matched))
+	for _, t := range matched {
+		rows = append(rows, NewTransactionRow(t))
+	}
+	return rows, nil
+}
+
+func NewTransactionRow(t trading212.HistoryTransactionItem) TransactionRow {
+	typ := ClassifyTransactionType(t.Type)
+
+	// The API sign convention varies by type; normalize to "positive = money in".
+	amount := t.Amount
+	switch typ {
+	case TxDeposit, TxInterest:
+		amount = Abs(amount)
+	case TxWithdrawal, TxFee:
+		amount = -Abs(amount)
+	}
+
+	return TransactionRow{
+		DateTime:  formatTime(t.DateTime),
+		Type:      typ,
+		RawType:   t.Type,
+		Amount:    amount,
+		Currency:  t.Currency,
+		Reference: t.Reference,
+	}
+}
+
+// ClassifyTransactionType maps a Trading212 transaction type to a normalized category.
+func ClassifyTransactionType(raw string) TransactionType {
+	t := strings.ToUpper(strings.TrimSpace(raw))
+	switch {
+	case t == "DEPOSIT":
+		return TxDeposit
+	case t == "WITHDRAW" || t == "WITHDRAWAL":
+		return TxWithdrawal
+	case strings.Contains(t, "INTEREST"):
+		return TxInterest
+	case strings.Contains(t, "FEE"):
+		return TxFee
+	case strings.Contains(t, "TRANSFER"):
+		return TxTransfer
+	default:
+		return TxOther
+	}
+}
+
+func SummarizeTransactions(rows []TransactionRow) TransactionTotals {
+	byType := make(map[TransactionType]*TransactionTypeTotal)
+	var t TransactionTotals
+	for _, r := range rows {
+		tt, ok := byType[r.Type]
+		if !ok {
+			tt = &TransactionTypeTotal{Type: r.Type}
+			byType[r.Type] = tt
+		}
+		tt.Count++
+		tt.Amount += r.Amount
+
+		switch r.Type {
+		case TxDeposit:
+			t.Deposits += r.Amount
+		case TxWithdrawal:
+			t.Withdrawals -= r.Amount
+		case TxInterest:
+			t.Interest += r.Amount
+		case TxFee:
+			t.Fees -= r.Amount
+		}
+	}
+
+	t.Deposits = Round(t.Deposits, 2)
+	t.Withdrawals = Round(t.Withdrawals, 2)
+	t.NetDeposits = Round(t.Deposits-t.Withdrawals, 2)
+	t.Interest = Round(t.Interest, 2)
+	t.Fees = Round(t.Fees, 2)
+
+	t.ByType = make([]TransactionTypeTotal, 0, len(byType))
+	for _, typ := range transactionTypeOrder {
+		if tt, ok := byType[typ]; ok {
+			tt.Amount = Round(tt.Amount, 2)
+			t.ByType = append(t.ByType, *tt)
+		}
+	}
+	return t
+}
@@ -0,0 +1,243 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Account returns are Modified Dietz approximations of TWR: folio212 has no price history to value
+// the account at each deposit or withdrawal, so sub-periods are only split at stored valuations.
+const (
+	TWRMethodHoldingsOnly = "holdings-only-no-flows"
+	// TWRMethodDietzLinked links Modified Dietz sub-period returns at stored valuations.
+	TWRMethodDietzLinked = "modified-dietz-linked"
+	// TWRMethodSinglePeriod is one Modified Dietz period from the start valuation to the end, used
+	// when no stored valuation falls inside the window to link at.
+	TWRMethodSinglePeriod = "modified-dietz-single-period"
+)
+
+// FlowAdjustedTWR reports whether a twrMethod accounts for deposits and withdrawals.
+func FlowAdjustedTWR(method string) bool {
+	return method == TWRMethodDietzLinked || method == TWRMethodSinglePeriod
+}
+
+// Valuation is the account value at a point in time.
+type Valuation struct {
+	At    time.Time
+	Value float64
+}
+
+// ValuationSource provides stored valuations for an account (e.g. from local snapshots).
+type ValuationSource interface {
+	Valuations(ctx context.Context, accountID int64) ([]Valuation, error)
+}
+
+// CashFlow is a dated external cash flow. Positive amounts are money into the account.
+type CashFlow struct {
+	At     time.Time
+	Amount float64
+}
+
+// ExternalFlows returns deposits and withdrawals as cash flows (oldest first).
+// Interest, fees and transfers are treated as part of performance, not external flows.
+func ExternalFlows(rows []TransactionRow) []CashFlow {
+	flows := make([]CashFlow, 0, len(rows))
+	for _, r := range rows {
+		if r.Type != TxDeposit && r.Type != TxWithdrawal {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, r.DateTime)
+		if err != nil {
+			continue
+		}
+		flows = append(flows, CashFlow{At: at, Amount: r.Amount})
+	}
+	sort.SliceStable(flows, func(i, j int) bool {
+		return flows[i].At.Before(flows[j].At)
+	})
+	return flows
+}
+
+// TimeWeightedReturn links sub-period returns between consecutive valuations.
+// Flows inside a sub-period are weighted by the fraction of the sub-period they were invested
+// (Modified Dietz), so the result is a true TWR only when a valuation exists at every flow and an
+// approximation otherwise. Returns ok=false when fewer than two valuations are given or a
+// sub-period has no capital at work.
+func TimeWeightedReturn(valuations []Valuation, flows []CashFlow) (pct float64, ok bool) {
+	if len(valuations) < 2 {
+		return 0, false
+	}
+	vals := append([]Valuation(nil), valuations...)
+	sort.SliceStable(vals, func(i, j int) bool {
+		return vals[i].At.Before(vals[j].At)
+	})
+
+	growth := 1.0
+	for i := 1; i < len(vals); i++ {
+		start, end := vals[i-1], vals[i]
+		span := end.At.Sub(start.At).Seconds()
+		if span <= 0 {
+			continue
+		}
+
+		var net, weighted float64
+		for _, f := range flows {
+			if !f.At.After(start.At) || f.At.After(end.At) {
+				continue
+			}
+			w := end.At.Sub(f.At).Seconds() / span
+			net += f.Amount
+			weighted += w * f.Amount
+		}
+
+		denom := start.Value + weighted
+		if denom <= 0 {
+			if start.Value == 0 && net == 0 {
+				continue // nothing invested yet
+			}
+			return 0, false
+		}
+		growth *= 1 + (end.Value-start.Value-net)/denom
+	}
+	return (growth - 1) * 100, true
+}
+
+type twrResult struct {
+	pct         float64
+	method      string
+	description string
+}
+
+func holdingsOnlyTWR(holdingsReturn float64, reason string) twrResult {
+	desc := "Estimated TWR based on holdings only; excludes cash flows and pie allocations."
+	if reason != "" {
+		desc += " Flow-adjusted TWR unavailable: " + reason + "."
+	}
+	return twrResult{pct: holdingsReturn, method: TWRMethodHoldingsOnly, description: desc}
+}
+
+// flowTWR approximates the account TWR from deposit/withdrawal history (oldest first) with Modified
+// Dietz, linked at the stored valuations inside the window. For all-time reports the account starts
+// at zero before the first deposit; a bounded period is measured from the last stored valuation at or
+// before its start (and, when it ended before now, to the last one at or before its end).
+func (s *Service) flowTWR(ctx context.Context, period PeriodRange, now time.Time, accountID int64, accountTotal, holdingsReturn float64, flows []CashFlow) (twrResult, error) {
+	from, to, bounded, err := period.Bounds()
+	if err != nil {
+		return twrResult{}, err
+	}
+	if len(flows) == 0 {
+		return holdingsOnlyTWR(holdingsReturn, "no deposits found"), nil
+	}
+
+	var stored []Valuation
+	if s.valuations != nil {
+		stored, err = s.valuations.Valuations(ctx, accountID)
+		if err != nil {
+			return twrResult{}, err
+		}
+	}
+
+	end := Valuation{At: now, Value: accountTotal}
+	if bounded && to.Before(now) {
+		v, ok := latestValuation(stored, to)
+		if !ok {
+			return holdingsOnlyTWR(holdingsReturn, "a period ending before today needs a stored snapshot from "+*period.To+" or earlier (run 'folio212 snapshot save' regularly)"), nil
+		}
+		end = v
+	}
+
+	// Account value is zero just before the first deposit.
+	start := Valuation{At: flows[0].At.Add(-time.Second), Value: 0}
+	if bounded {
+		v, ok := latestValuation(stored, from)
+		if !ok {
+			return holdingsOnlyTWR(holdingsReturn, "a period needs a stored snapshot taken at or before its start, "+*period.From+" (run 'folio212 snapshot save' regularly)"), nil
+		}
+		start = v
+	}
+
+	points := []Valuation{start}
+	for _, v := range stored {
+		if v.At.After(start.At) && v.At.Before(end.At) {
+			points = append(points, v)
+		}
+	}
+	points = append(points, end)
+
+	window := make([]CashFlow, 0, len(flows))
+	for _, f := range flows {
+		if f.At.After(start.At) && !f.At.After(end.At) {
+			window = append(window, f)
+		}
+	}
+
+	pct, ok := TimeWeightedReturn(points, window)
+	if !ok {
+		return holdingsOnlyTWR(holdingsReturn, "no capital at work in a sub-period"), nil
+	}
+	dates := start.At.Local().Format(dateLayout) + " to " + end.At.Local().Format(dateLayout)
+	if len(points) == 2 {
+		return twrResult{
+			pct:    pct,
+			method: TWRMethodSinglePeriod,
+			description: "Modified Dietz approximation of account TWR over one period, " + dates + ": deposits and " +
+				"withdrawals are weighted by how long they were invested; no stored valuation falls inside the period to link at. " +
+				"Save snapshots regularly (folio212 snapshot save) to link more often.",
+		}, nil
+	}
+	return twrResult{
+		pct:    pct,
+		method: TWRMethodDietzLinked,
+		description: fmt.Sprintf("Modified Dietz approximation of account TWR, %s, linked at %d stored valuations: deposits "+
+			"and withdrawals are weighted by how long they were invested within each sub-period. Exact only with a "+
+			"valuation at every deposit and withdrawal.", dates, len(points)-2),
+	}, nil
+}
+
+// accountFlows returns all deposits and withdrawals, oldest first. Missing permissions and rate
+// limits are reported as a non-empty reason rather than an error so reports can degrade.
+func (s *Service) accountFlows(ctx context.Context) (flows []CashFlow, unavailable string, err error) {
+	items, err := s.client.GetTransactions(ctx, time.Time{})
+	if err != nil {
+		err = classifyHistoryError(err)
+		if reason, ok := historyUnavailable(err, "transaction history"); ok {
+			return nil, reason, nil
+		}
+		return nil, "", err
+	}
+	rows := make([]TransactionRow, 0, len(items))
+	for _, t := range items {
+		rows = append(rows, NewTransactionRow(t))
+	}
+	return ExternalFlows(rows), "", nil
+}
+
+// historyUnavailable maps history errors that should degrade a report to a short reason.
+func historyUnavailable(err error, what string) (string, bool) {
+	switch {
+	case errors.Is(err, ErrMissingHistoryPermission):
+		return "missing History permission", true
+	case errors.Is(err, ErrRateLimited):
+		return what + " rate limited", true
+	}
+	return "", false
+}
+
+// latestValuation returns the most recent valuation at or before t.
+func latestValuation(vals []Valuation, t time.Time) (Valuation, bool) {
+	var best Valuation
+	found := false
+	for _, v := range vals {
+		if v.At.After(t) {
+			continue
+		}
+		if !found || v.At.After(best.At) {
+			best = v
+			found = true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,98 @@
+package portfolio
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeValuations []Valuation
+
+func (f fakeValuations) Valuations(context.Context, int64) ([]Valuation, error) {
+	return f, nil
+}
+
+func day(s string) time.Time {
+	t, err := time.ParseInLocation(dateLayout, s, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestTimeWeightedReturnChainsSubPeriods(t *testing.T) {
+	// +10% on 1000, then a 1000 deposit at the valuation point, then +10% on 2100.
+	vals := []Valuation{
+		{At: day("2025-01-01"), Value: 1000},
+		{At: day("2025-02-01"), Value: 2100},
+		{At: day("2025-03-01"), Value: 2310},
+	}
+	flows := []CashFlow{{At: day("2025-02-01"), Amount: 1000}}
+	pct, ok := TimeWeightedReturn(vals, flows)
+	if !ok {
+		t.Fatal("ok = false")
+	}
+	if math.Abs(pct-21) > 1e-9 {
+		t.Errorf("pct = %v, want 21", pct)
+	}
+}
+
+func TestFlowTWRMethod(t *testing.T) {
+	now := day("2025-04-01")
+	flows := []CashFlow{
+		{At: day("2025-01-01"), Amount: 1000},
+		{At: day("2025-02-15"), Amount: 500},
+	}
+	ptr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name       string
+		period     PeriodRange
+		stored     fakeValuations
+		wantMethod string
+		wantReason string
+	}{
+		{
+			name:       "all time without valuations is a single Dietz period",
+			wantMethod: TWRMethodSinglePeriod,
+		},
+		{
+			name:       "all time with a valuation inside is chained",
+			stored:     fakeValuations{{At: day("2025-02-01"), Value: 1050}},
+			wantMethod: TWRMethodDietzLinked,
+		},
+		{
+			name:       "bounded without a start valuation falls back",
+			period:     PeriodRange{From: ptr("2025-02-01"), To: ptr("2025-04-01")},
+			stored:     fakeValuations{{At: day("2025-03-01"), Value: 1600}},
+			wantMethod: TWRMethodHoldingsOnly,
+			wantReason: "snapshot taken at or before its start, 2025-02-01",
+		},
+		{
+			name:       "bounded with only a start valuation is a single Dietz period",
+			period:     PeriodRange{From: ptr("2025-02-01"), To: ptr("2025-04-01")},
+			stored:     fakeValuations{{At: day("2025-01-31"), Value: 1050}},
+			wantMethod: TWRMethodSinglePeriod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(nil, WithValuations(tt.stored))
+			got, err := s.flowTWR(context.Background(), tt.period, now, 1, 1700, 5, flows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.method != tt.wantMethod {
+				t.Errorf("method = %q, want %q (%s)", got.method, tt.wantMethod, got.description)
+			}
+			if tt.wantReason != "" && !strings.Contains(got.description, tt.wantReason) {
+				t.Errorf("description = %q, want it to mention %q", got.description, tt.wantReason)
+			}
+			if FlowAdjustedTWR(got.method) && got.pct == 5 {
+				t.Errorf("flow-adjusted pct equals the holdings return")
+			}
+		})
+	}
+}
@@ -35,6 +35,10 @@ type DerivedMetrics struct {
 	TWRBpsEst         int     `json:"twrBpsEst"`
 	TWRMethod         string  `json:"twrMethod"`
 	TWRDescription    string  `json:"twrDescription,omitempty"`
+
+	// Money-weighted return (annualized XIRR); null unless requested and history is available.
+	MWRPct         *float64 `json:"mwrPct,omitempty"`
+	MWRDescription string   `json:"mwrDescription,omitempty"`
 }
 
 type APISnapshot struct {
@@ -54,6 +58,7 @@ type Reconciliation struct {
 }
 
 type Summary struct {
+	AccountID      int64          `json:"accountId,omitempty"`
 	Currency       string         `json:"currency"`
 	Derived        DerivedMetrics `json:"derived"`
 	Snapshot       APISnapshot    `json:"snapshot"`
@@ -88,15 +93,59 @@ type HoldingRow struct {
 	FXPair          string   `json:"fxPair,omitempty"` // e.g. "USD/EUR"
 	HoldingsPct     float64  `json:"holdingsPct"`
 	HoldingsBps     int      `json:"holdingsBps"`
+	MWRPct          *float64 `json:"mwrPct,omitempty"` // annualized XIRR of buys, sells and dividends
+
+	// Per-account breakdown; only set on consolidated (--all-profiles) reports.
+	Accounts []HoldingAccount `json:"accounts,omitempty"`
+}
+
+// HoldingAccount is one account's share of a consolidated holding (reporting currency).
+type HoldingAccount struct {
+	Profile       string  `json:"profile"`
+	AccountID     int64   `json:"accountId,omitempty"`
+	Ticker        string  `json:"ticker"`
+	Qty           float64 `json:"qty"`
+	Invested      float64 `json:"invested"`
+	MarketValue   float64 `json:"marketValue"`
+	UnrealizedPnL float64 `json:"unrealizedPnL"`
+}
+
+// AccountSection describes one account of a consolidated report. Derived metrics and the
+// reconciliation are in the account's own currency; FXRate converts them to the reporting currency.
+type AccountSection struct {
+	Profile        string         `json:"profile"`
+	AccountID      int64          `json:"accountId,omitempty"`
+	Currency       string         `json:"currency"`
+	FXRate         float64        `json:"fxRate"`        // reporting-currency units per 1 account-currency unit
+	FXSource       string         `json:"fxSource"`      // same, explicit or implied (from position prices)
+	AccountTotal   float64        `json:"accountTotal"`  // reporting currency
+	HoldingsValue  float64        `json:"holdingsValue"` // reporting currency
+	Derived        DerivedMetrics `json:"derived"`
+	Reconciliation Reconciliation `json:"reconcile"`
+	Raw            *RawData       `json:"raw,omitempty"`
+}
+
+// PeriodFlows aggregates executed trades within the reporting period (account currency).
+// Available is false when order history could not be read (e.g. missing History permission).
+type PeriodFlows struct {
+	Available  bool     `json:"available"`
+	OrderCount int      `json:"orderCount"`
+	Buys       float64  `json:"buys"`
+	Sells      float64  `json:"sells"`
+	Fees       float64  `json:"fees"`
+	Net        float64  `json:"net"` // buys - sells
+	Warnings   []string `json:"warnings,omitempty"`
 }
 
 type Output struct {
-	SchemaVersion int             `json:"schemaVersion"`
-	Report        Report          `json:"report"`
-	Summary       Summary         `json:"summary"`
-	Allocation    []AllocationRow `json:"allocation"`
-	Holdings      []HoldingRow    `json:"holdings"`
-	Raw           *RawData        `json:"raw,omitempty"`
+	SchemaVersion int              `json:"schemaVersion"`
+	Report        Report           `json:"report"`
+	Summary       Summary          `json:"summary"`
+	Accounts      []AccountSection `json:"accounts,omitempty"`    // consolidated (--all-profiles) reports only
+	PeriodFlows   *PeriodFlows     `json:"periodFlows,omitempty"` // null for all-time reports
+	Allocation    []AllocationRow  `json:"allocation"`
+	Holdings      []HoldingRow     `json:"holdings"`
+	Raw           *RawData         `json:"raw,omitempty"`
 }
 
 type RawData struct {
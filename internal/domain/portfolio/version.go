@@ -0,0 +1,100 @@
+package portfolio
+
+import (
+	"fmt"
+	"slices"
+)
+
+// outputVersion is one published shape of the portfolio JSON output.
+type outputVersion struct {
+	// model is a zero value of the type whose JSON encoding is this version (used for its schema).
+	model any
+	// adapt converts a current report to this version; nil for SchemaVersion itself.
+	adapt func(*Output) any
+}
+
+// outputVersions holds every version callers can pin with --schema-version. When the Output
+// shape changes incompatibly, bump SchemaVersion, freeze the previous types (e.g. OutputV1) and
+// register an adapter from the current Output to them here. Versions older than SchemaVersion
+// are deprecated and dropped after a release or two.
+var outputVersions = map[int]outputVersion{
+	SchemaVersion: {model: Output{}},
+}
+
+func init() {
+	if err := checkOutputVersions(outputVersions, SchemaVersion); err != nil {
+		panic(err)
+	}
+}
+
+// checkOutputVersions refuses a registry without the current version, with a version newer than
+// it, or with an older version that has no adapter (requesting it would otherwise fail at run time).
+func checkOutputVersions(versions map[int]outputVersion, current int) error {
+	if _, ok := versions[current]; !ok {
+		return fmt.Errorf("schema version %d (current) is not registered", current)
+	}
+	for version, v := range versions {
+		switch {
+		case version > current:
+			return fmt.Errorf("schema version %d is newer than the current version %d", version, current)
+		case v.model == nil:
+			return fmt.Errorf("schema version %d has no model", version)
+		case version < current && v.adapt == nil:
+			return fmt.Errorf("schema version %d has no adapter from version %d", version, current)
+		}
+	}
+	return nil
+}
+
+// SupportedSchemaVersions lists the output versions that can be requested, oldest first.
+func SupportedSchemaVersions() []int {
+	versions := make([]int, 0, len(outputVersions))
+	for v := range outputVersions {
+		versions = append(versions, v)
+	}
+	slices.Sort(versions)
+	return versions
+}
+
+func lookupOutputVersion(version int) (outputVersion, error) {
+	v, ok := outputVersions[version]
+	if !ok {
+		if version > SchemaVersion {
+			return v, fmt.Errorf("%w: %d is newer than this folio212 (latest: %d)", ErrUnsupportedSchemaVersion, version, SchemaVersion)
+		}
+		return v, fmt.Errorf("%w: %d (supported: %v)", ErrUnsupportedSchemaVersion, version, SupportedSchemaVersions())
+	}
+	return v, nil
+}
+
+// VersionedOutput returns output in the shape of the given schema version (0 = current). For an
+// older version it also returns a deprecation notice for the caller to show.
+func VersionedOutput(output *Output, version int) (value any, deprecation string, err error) {
+	if version == 0 || version == SchemaVersion {
+		return output, "", nil
+	}
+	v, err := lookupOutputVersion(version)
+	if err != nil {
+		return nil, "", err
+	}
+	return v.adapt(output), DeprecationNotice(version), nil
+}
+
+// DeprecationNotice describes why an older schema version should be migrated away from; empty
+// for the current version.
+func DeprecationNotice(version int) string {
+	if version >= SchemaVersion {
+		return ""
+	}
+	return fmt.Sprintf("schema version %d is deprecated and will be removed in a future release; "+
+		"migrate to version %d (see 'folio212 schema --version %d')", version, SchemaVersion, SchemaVersion)
+}
+
+// CheckSchemaVersion reports whether version (0 = current) can be requested.
+func CheckSchemaVersion(version int) error {
+	if version == 0 {
+		return nil
+	}
+	_, err := lookupOutputVersion(version)
+	return err
+}
@@ -0,0 +1,77 @@
+package portfolio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVersionedOutputCurrent(t *testing.T) {
+	output := &Output{SchemaVersion: SchemaVersion}
+	for _, version := range []int{0, SchemaVersion} {
+		value, notice, err := VersionedOutput(output, version)
+		if err != nil || notice != "" || value != output {
+			t.Errorf("version %d: value = %p, notice = %q, err = %v; want the report itself", version, value, notice, err)
+		}
+	}
+	for _, version := range []int{-1, SchemaVersion + 1} {
+		if _, _, err := VersionedOutput(output, version); !errors.Is(err, ErrUnsupportedSchemaVersion) {
+			t.Errorf("version %d: err = %v", version, err)
+		}
+	}
+}
+
+func TestCheckOutputVersions(t *testing.T) {
+	adapt := func(o *Output) any { return o }
+	tests := []struct {
+		name     string
+		versions map[int]outputVersion
+		current  int
+		wantErr  string
+	}{
+		{name: "registry", versions: outputVersions, current: SchemaVersion},
+		{
+			name:     "older version with adapter",
+			versions: map[int]outputVersion{1: {model: Output{}, adapt: adapt}, 2: {model: Output{}}},
+			current:  2,
+		},
+		{
+			name:     "missing adapter",
+			versions: map[int]outputVersion{1: {model: Output{}}, 2: {model: Output{}}},
+			current:  2,
+			wantErr:  "has no adapter",
+		},
+		{
+			name:     "missing model",
+			versions: map[int]outputVersion{1: {}},
+			current:  1,
+			wantErr:  "has no model",
+		},
+		{
+			name:     "missing current",
+			versions: map[int]outputVersion{1: {model: Output{}, adapt: adapt}},
+			current:  2,
+			wantErr:  "is not registered",
+		},
+		{
+			name:     "newer than current",
+			versions: map[int]outputVersion{1: {model: Output{}}, 2: {model: Output{}}},
+			current:  1,
+			wantErr:  "is newer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkOutputVersions(tt.versions, tt.current)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
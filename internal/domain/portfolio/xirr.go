@@ -0,0 +1,116 @@
+package portfolio
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	xirrMaxIterations = 100
+	xirrTolerance     = 1e-9
+	daysPerYear       = 365.0
+)
+
+// MoneyWeightedReturn returns the annualized money-weighted return (XIRR) in percent.
+// flows use the "positive = money into the investment" convention (deposits, buys); sells,
+// withdrawals and dividends are negative. marketValue is what the investment is worth at asOf
+// and is treated as a final inflow to the investor.
+// ok=false when there is nothing to solve (no money in or out) or the solver does not converge.
+func MoneyWeightedReturn(flows []CashFlow, marketValue float64, asOf time.Time) (pct float64, ok bool) {
+	investor := make([]CashFlow, 0, len(flows)+1)
+	for _, f := range flows {
+		if f.Amount == 0 || f.At.After(asOf) {
+			continue
+		}
+		investor = append(investor, CashFlow{At: f.At, Amount: -f.Amount})
+	}
+	if marketValue != 0 {
+		investor = append(investor, CashFlow{At: asOf, Amount: marketValue})
+	}
+
+	rate, ok := XIRR(investor)
+	if !ok {
+		return 0, false
+	}
+	return rate * 100, true
+}
+
+// XIRR solves for the annual rate r where the net present value of the dated flows is zero.
+// Flows are from the investor's perspective (negative = paid in, positive = received) and must
+// contain at least one of each sign, at more than one time. The rate is returned as a fraction
+// (0.05 = 5%).
+func XIRR(flows []CashFlow) (float64, bool) {
+	if len(flows) < 2 {
+		return 0, false
+	}
+	sorted := append([]CashFlow(nil), flows...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].At.Before(sorted[j].At)
+	})
+	if !sorted[len(sorted)-1].At.After(sorted[0].At) {
+		// Without elapsed time every rate discounts equally; there is nothing to solve for.
+		return 0, false
+	}
+
+	var hasPos, hasNeg bool
+	years := make([]float64, len(sorted))
+	for i, f := range sorted {
+		years[i] = f.At.Sub(sorted[0].At).Hours() / 24 / daysPerYear
+		hasPos = hasPos || f.Amount > 0
+		hasNeg = hasNeg || f.Amount < 0
+	}
+	if !hasPos || !hasNeg {
+		return 0, false
+	}
+
+	npv := func(r float64) (value, derivative float64) {
+		for i, f := range sorted {
+			d := math.Pow(1+r, years[i])
+			value += f.Amount / d
+			derivative -= years[i] * f.Amount / (d * (1 + r))
+		}
+		return value, derivative
+	}
+
+	// Newton-Raphson from a moderate guess; fast when it converges.
+	r := 0.1
+	for range xirrMaxIterations {
+		v, d := npv(r)
+		if math.Abs(v) < xirrTolerance {
+			return r, true
+		}
+		if d == 0 || math.IsNaN(d) || math.IsInf(d, 0) {
+			break
+		}
+		next := r - v/d
+		if next <= -1 || math.IsNaN(next) || math.IsInf(next, 0) {
+			break
+		}
+		if math.Abs(next-r) < xirrTolerance {
+			return next, true
+		}
+		r = next
+	}
+
+	// Fall back to bisection over a wide bracket.
+	lo, hi := -0.999999, 1e6
+	vlo, _ := npv(lo)
+	vhi, _ := npv(hi)
+	if math.Signbit(vlo) == math.Signbit(vhi) {
+		return 0, false
+	}
+	for range 1000 {
+		mid := (lo + hi) / 2
+		vmid, _ := npv(mid)
+		if math.Abs(vmid) < xirrTolerance || (hi-lo)/2 < xirrTolerance {
+			return mid, true
+		}
+		if math.Signbit(vmid) == math.Signbit(vlo) {
+			lo, vlo = mid, vmid
+		} else {
+			hi = mid
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,146 @@
+package portfolio
+
+import (
+	"math"
+	"testing"
+)
+
+func TestXIRR(t *testing.T) {
+	tests := []struct {
+		name   string
+		flows  []CashFlow
+		want   float64
+		wantOK bool
+	}{
+		{
+			name:   "one year at 10%",
+			flows:  []CashFlow{{At: day("2025-01-01"), Amount: -1000}, {At: day("2026-01-01"), Amount: 1100}},
+			want:   0.10,
+			wantOK: true,
+		},
+		{
+			// The XIRR example from the spreadsheet documentation.
+			name: "multiple flows",
+			flows: []CashFlow{
+				{At: day("2008-01-01"), Amount: -10000},
+				{At: day("2008-03-01"), Amount: 2750},
+				{At: day("2008-10-30"), Amount: 4250},
+				{At: day("2009-02-15"), Amount: 3250},
+				{At: day("2009-04-01"), Amount: 2750},
+			},
+			want:   0.373362535,
+			wantOK: true,
+		},
+		{
+			name: "unsorted flows with two on the same day",
+			flows: []CashFlow{
+				{At: day("2026-01-01"), Amount: 1100},
+				{At: day("2025-01-01"), Amount: -400},
+				{At: day("2025-01-01"), Amount: -600},
+			},
+			want:   0.10,
+			wantOK: true,
+		},
+		{
+			name:   "under one year is annualized",
+			flows:  []CashFlow{{At: day("2025-01-01"), Amount: -1000}, {At: day("2025-07-02"), Amount: 1050}},
+			want:   math.Pow(1.05, daysPerYear/182) - 1,
+			wantOK: true,
+		},
+		{
+			name:   "total loss",
+			flows:  []CashFlow{{At: day("2025-01-01"), Amount: -1000}, {At: day("2026-01-01"), Amount: 1}},
+			want:   -0.999,
+			wantOK: true,
+		},
+		{
+			name:  "all flows on one day",
+			flows: []CashFlow{{At: day("2025-01-01"), Amount: -1000}, {At: day("2025-01-01"), Amount: 1000}},
+		},
+		{
+			name:  "no money received",
+			flows: []CashFlow{{At: day("2025-01-01"), Amount: -1000}, {At: day("2025-06-01"), Amount: -500}},
+		},
+		{
+			name:  "no money paid in",
+			flows: []CashFlow{{At: day("2025-01-01"), Amount: 1000}, {At: day("2025-06-01"), Amount: 500}},
+		},
+		{
+			name:  "single flow",
+			flows: []CashFlow{{At: day("2025-01-01"), Amount: -1000}},
+		},
+		{
+			// NPV is negative at every rate, so there is no root to converge to.
+			name: "no root",
+			flows: []CashFlow{
+				{At: day("2025-01-01"), Amount: -1000},
+				{At: day("2026-01-01"), Amount: 3000},
+				{At: day("2027-01-01"), Amount: -2500},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := XIRR(tt.flows)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v (rate %v), want %v", ok, got, tt.wantOK)
+			}
+			if ok && math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("rate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyWeightedReturn(t *testing.T) {
+	asOf := day("2026-01-01")
+	tests := []struct {
+		name        string
+		flows       []CashFlow
+		marketValue float64
+		want        float64
+		wantOK      bool
+	}{
+		{
+			name:        "deposit grows 10% in a year",
+			flows:       []CashFlow{{At: day("2025-01-01"), Amount: 1000}},
+			marketValue: 1100,
+			want:        10,
+			wantOK:      true,
+		},
+		{
+			name:        "flows after asOf are ignored",
+			flows:       []CashFlow{{At: day("2025-01-01"), Amount: 1000}, {At: day("2026-02-01"), Amount: 10000}},
+			marketValue: 1100,
+			want:        10,
+			wantOK:      true,
+		},
+		{
+			name:        "sold out with a gain",
+			flows:       []CashFlow{{At: day("2025-01-01"), Amount: 1000}, {At: day("2026-01-01"), Amount: -1200}},
+			marketValue: 0,
+			want:        20,
+			wantOK:      true,
+		},
+		{
+			name:        "zero flows are ignored",
+			flows:       []CashFlow{{At: day("2025-01-01"), Amount: 0}},
+			marketValue: 1100,
+		},
+		{
+			name:        "nothing in or out",
+			marketValue: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MoneyWeightedReturn(tt.flows, tt.marketValue, asOf)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v (pct %v), want %v", ok, got, tt.wantOK)
+			}
+			if ok && math.Abs(got-tt.want) > 1e-4 {
+				t.Errorf("pct = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,43 @@ type Config struct {
 	Workspace        string `mapstructure:"workspace" yaml:"workspace,omitempty"`
 	Trading212Env    string `mapstructure:"trading212_env" yaml:"trading212_env,omitempty"` // "demo" or "live"
 	Trading212APIKey string `mapstructure:"trading212_api_key" yaml:"trading212_api_key,omitempty"`
+	// Account ID of the default profile; see Profile.Trading212AccountID.
+	Trading212AccountID int64 `mapstructure:"trading212_account_id" yaml:"trading212_account_id,omitempty"`
+
+	// Named profiles for additional accounts; selected with --profile.
+	DefaultProfile string             `mapstructure:"default_profile" yaml:"default_profile,omitempty"`
+	Profiles       map[string]Profile `mapstructure:"profiles" yaml:"profiles,omitempty"`
+
+	// Default rules for 'folio212 check'; flags override them.
+	Check *CheckConfig `mapstructure:"check" yaml:"check,omitempty"`
+
+	// Webhooks for 'folio212 notify'.
+	Notify *NotifyConfig `mapstructure:"notify" yaml:"notify,omitempty"`
+}
+
+type CheckConfig struct {
+	MaxDriftBps     *int     `mapstructure:"max_drift_bps" yaml:"max_drift_bps,omitempty"`
+	MaxPositionPct  *float64 `mapstructure:"max_position_pct" yaml:"max_position_pct,omitempty"`
+	MaxFreeCash     *float64 `mapstructure:"max_free_cash" yaml:"max_free_cash,omitempty"`
+	FailOnReconcile bool     `mapstructure:"fail_on_reconcile" yaml:"fail_on_reconcile,omitempty"`
+	Targets         string   `mapstructure:"targets" yaml:"targets,omitempty"` // targets file for the drift rule
+}
+
+type NotifyConfig struct {
+	Retries  *int            `mapstructure:"retries" yaml:"retries,omitempty"` // extra attempts after a failure (default 3)
+	Timeout  string          `mapstructure:"timeout" yaml:"timeout,omitempty"` // per request, Go duration (default 10s)
+	Webhooks []WebhookConfig `mapstructure:"webhooks" yaml:"webhooks"`
+}
+
+type WebhookConfig struct {
+	Name        string            `mapstructure:"name" yaml:"name"`
+	URL         string            `mapstructure:"url" yaml:"url,omitempty"`
+	URLEnv      string            `mapstructure:"url_env" yaml:"url_env,omitempty"` // read the URL from this environment variable
+	Method      string            `mapstructure:"method" yaml:"method,omitempty"`   // default POST
+	Headers     map[string]string `mapstructure:"headers" yaml:"headers,omitempty"`
+	Template    string            `mapstructure:"template" yaml:"template,omitempty"` // Go text/template for the body; default is the JSON payload
+	ContentType string            `mapstructure:"content_type" yaml:"content_type,omitempty"`
+	Events      []string          `mapstructure:"events" yaml:"events,omitempty"` // summary, violations; empty = all
 }
 
 var (
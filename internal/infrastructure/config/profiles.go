@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/shared/constants"
+)
+
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile holds the connection settings for one Trading212 account.
+type Profile struct {
+	Name             string `mapstructure:"-" yaml:"-"`
+	Trading212Env    string `mapstructure:"trading212_env" yaml:"trading212_env,omitempty"` // "demo" or "live"
+	Trading212APIKey string `mapstructure:"trading212_api_key" yaml:"trading212_api_key,omitempty"`
+	// Trading212AccountID is learned from the API (init, snapshot save) and scopes local snapshots
+	// to this profile's account; 0 = not known yet.
+	Trading212AccountID int64 `mapstructure:"trading212_account_id" yaml:"trading212_account_id,omitempty"`
+}
+
+// ProfileName resolves an empty name to the configured default profile.
+func (c *Config) ProfileName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name != "" {
+		return name
+	}
+	if d := strings.ToLower(strings.TrimSpace(c.DefaultProfile)); d != "" {
+		return d
+	}
+	return constants.DefaultProfileName
+}
+
+// Profile returns the named profile ("" selects the default profile).
+func (c *Config) Profile(name string) (Profile, error) {
+	name = c.ProfileName(name)
+	if p, ok := c.Profiles[name]; ok {
+		p.Name = name
+		return p, nil
+	}
+	if name == constants.DefaultProfileName {
+		return Profile{
+			Name:                name,
+			Trading212Env:       c.Trading212Env,
+			Trading212APIKey:    c.Trading212APIKey,
+			Trading212AccountID: c.Trading212AccountID,
+		}, nil
+	}
+	return Profile{}, fmt.Errorf("%w: %q (known: %s)", ErrProfileNotFound, name, strings.Join(c.ProfileNames(), ", "))
+}
+
+// SetProfile adds or replaces a profile. The default profile is written to the top-level fields;
+// the first named profile of an empty config becomes default_profile.
+func (c *Config) SetProfile(name string, p Profile) {
+	name = c.ProfileName(name)
+	if name != constants.DefaultProfileName && c.DefaultProfile == "" && len(c.ProfileNames()) == 0 {
+		c.DefaultProfile = name
+	}
+	if _, ok := c.Profiles[name]; !ok && name == constants.DefaultProfileName {
+		c.Trading212Env = p.Trading212Env
+		c.Trading212APIKey = p.Trading212APIKey
+		c.Trading212AccountID = p.Trading212AccountID
+		return
+	}
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]Profile)
+	}
+	p.Name = ""
+	c.Profiles[name] = p
+}
+
+// ProfileNames lists configured profiles in alphabetical order.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles)+1)
+	if _, ok := c.Profiles[constants.DefaultProfileName]; !ok && strings.TrimSpace(c.Trading212APIKey) != "" {
+		names = append(names, constants.DefaultProfileName)
+	}
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,43 @@
+package config
+
+import "testing"
+
+func TestSetProfileDefault(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		profile     string
+		wantDefault string
+	}{
+		{name: "first named profile becomes the default", profile: "isa", wantDefault: "isa"},
+		{name: "default profile leaves default_profile unset", profile: "default"},
+		{
+			name:    "existing top-level profile is kept as default",
+			cfg:     Config{Trading212APIKey: "key"},
+			profile: "isa",
+		},
+		{
+			name:    "existing named profile is kept as default",
+			cfg:     Config{Profiles: map[string]Profile{"demo": {Trading212APIKey: "key"}}},
+			profile: "isa",
+		},
+		{
+			name:        "explicit default_profile is kept",
+			cfg:         Config{DefaultProfile: "demo"},
+			profile:     "isa",
+			wantDefault: "demo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.cfg
+			c.SetProfile(tt.profile, Profile{Trading212Env: "live", Trading212APIKey: "new"})
+			if c.DefaultProfile != tt.wantDefault {
+				t.Errorf("default_profile = %q, want %q", c.DefaultProfile, tt.wantDefault)
+			}
+			if p, err := c.Profile(tt.profile); err != nil || p.Trading212APIKey != "new" {
+				t.Errorf("Profile(%q) = %+v, %v", tt.profile, p, err)
+			}
+		})
+	}
+}
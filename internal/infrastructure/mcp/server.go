@@ -0,0 +1,372 @@
+// Package mcp is a minimal Model Context Protocol server: JSON-RPC 2.0 messages, one per line,
+// over stdin/stdout, serving tools and static resources.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ProtocolVersion is the newest protocol revision the server speaks. Older clients get their own
+// revision back when it is in supportedVersions.
+const ProtocolVersion = "2025-06-18"
+
+var supportedVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// ErrInvalidArguments marks tool argument errors; they are returned as JSON-RPC "invalid params"
+// errors instead of tool results.
+var ErrInvalidArguments = errors.New("invalid arguments")
+
+// JSON-RPC error codes.
+const (
+	codeParseError       = -32700
+	codeInvalidRequest   = -32600
+	codeMethodNotFound   = -32601
+	codeInvalidParams    = -32602
+	codeInternalError    = -32603
+	codeResourceNotFound = -32002
+)
+
+// maxMessageSize bounds a single incoming line.
+const maxMessageSize = 4 << 20
+
+// Tool is a callable tool. Handler receives the raw "arguments" object (never nil) and returns a
+// JSON-marshalable result; errors wrapping ErrInvalidArguments become protocol errors, any other
+// error is reported to the model as a failed tool result.
+type Tool struct {
+	Name        string
+	Title       string
+	Description string
+	InputSchema json.RawMessage
+	ReadOnly    bool
+	Handler     func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// Resource is a static document served by URI.
+type Resource struct {
+	URI         string
+	Name        string
+	Description string
+	MimeType    string
+	Read        func() (string, error)
+}
+
+type Server struct {
+	name         string
+	version      string
+	instructions string
+	tools        []Tool
+	resources    []Resource
+	mu           sync.Mutex // guards writes
+}
+
+type Option func(*Server)
+
+// WithTools registers tools in the order they are listed to clients.
+func WithTools(tools ...Tool) Option {
+	return func(s *Server) {
+		s.tools = append(s.tools, tools...)
+	}
+}
+
+// WithResources registers resources in the order they are listed to clients.
+func WithResources(resources ...Resource) Option {
+	return func(s *Server) {
+		s.resources = append(s.resources, resources...)
+	}
+}
+
+// WithInstructions sets the usage hint returned from initialize.
+func WithInstructions(text string) Option {
+	return func(s *Server) {
+		s.instructions = text
+	}
+}
+
+func NewServer(name, version string, opts ...Option) *Server {
+	s := &Server{name: name, version: version}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	return s
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// Serve handles requests from r until EOF or ctx is cancelled. Requests are handled one at a
+// time; responses are written to w as single lines.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if resp := s.handle(ctx, line); resp != nil {
+			if err := s.write(w, resp); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read request: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) write(w io.Writer, resp *response) error {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		b, _ = json.Marshal(&response{JSONRPC: "2.0", ID: resp.ID, Error: &rpcError{Code: codeInternalError, Message: err.Error()}})
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// handle returns nil for notifications.
+func (s *Server) handle(ctx context.Context, line []byte) *response {
+	null := json.RawMessage("null")
+	if line[0] == '[' {
+		return &response{JSONRPC: "2.0", ID: null, Error: &rpcError{Code: codeInvalidRequest, Message: "batch requests are not supported"}}
+	}
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &response{JSONRPC: "2.0", ID: null, Error: &rpcError{Code: codeParseError, Message: "parse error: " + err.Error()}}
+	}
+	notification := len(req.ID) == 0
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if notification {
+			return nil
+		}
+		return &response{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: codeInvalidRequest, Message: `invalid request: expected jsonrpc "2.0" and a method`}}
+	}
+
+	result, err := s.dispatch(ctx, req)
+	if notification {
+		return nil
+	}
+	resp := &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{Code: codeInternalError, Message: err.Error()}
+		}
+		resp.Result = nil
+		resp.Error = rerr
+	}
+	return resp
+}
+
+func (s *Server) dispatch(ctx context.Context, req request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(req.Params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	case "resources/list":
+		return s.listResources(), nil
+	case "resources/templates/list":
+		return map[string]any{"resourceTemplates": []any{}}, nil
+	case "resources/read":
+		return s.readResource(req.Params)
+	}
+	if strings.HasPrefix(req.Method, "notifications/") {
+		return nil, nil // initialized, cancelled, ...: nothing to do
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+}
+
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	version := ProtocolVersion
+	if slices.Contains(supportedVersions, p.ProtocolVersion) {
+		version = p.ProtocolVersion
+	}
+	capabilities := map[string]any{}
+	if len(s.tools) > 0 {
+		capabilities["tools"] = map[string]any{"listChanged": false}
+	}
+	if len(s.resources) > 0 {
+		capabilities["resources"] = map[string]any{"listChanged": false, "subscribe": false}
+	}
+	result := map[string]any{
+		"protocolVersion": version,
+		"capabilities":    capabilities,
+		"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+	}
+	if s.instructions != "" {
+		result["instructions"] = s.instructions
+	}
+	return result, nil
+}
+
+func (s *Server) listTools() any {
+	tools := make([]map[string]any, 0, len(s.tools))
+	for _, t := range s.tools {
+		tool := map[string]any{
+			"name":        t.Name,
+			"description": t.Description,
+			"inputSchema": t.InputSchema,
+			"annotations": map[string]any{
+				"readOnlyHint":    t.ReadOnly,
+				"destructiveHint": false,
+				"openWorldHint":   true,
+			},
+		}
+		if t.Title != "" {
+			tool["title"] = t.Title
+		}
+		tools = append(tools, tool)
+	}
+	return map[string]any{"tools": tools}
+}
+
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	idx := slices.IndexFunc(s.tools, func(t Tool) bool { return t.Name == p.Name })
+	if idx < 0 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %q", p.Name)}
+	}
+	args := p.Arguments
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage("{}")
+	}
+
+	value, err := s.tools[idx].Handler(ctx, args)
+	if errors.Is(err, ErrInvalidArguments) {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	if err != nil {
+		return map[string]any{
+			"content": []textContent{{Type: "text", Text: err.Error()}},
+			"isError": true,
+		}, nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]any{
+		"content": []textContent{{Type: "text", Text: string(b)}},
+		"isError": false,
+	}
+	if len(b) > 0 && b[0] == '{' {
+		result["structuredContent"] = json.RawMessage(b)
+	}
+	return result, nil
+}
+
+func (s *Server) listResources() any {
+	resources := make([]map[string]any, 0, len(s.resources))
+	for _, r := range s.resources {
+		res := map[string]any{"uri": r.URI, "name": r.Name, "mimeType": r.MimeType}
+		if r.Description != "" {
+			res["description"] = r.Description
+		}
+		resources = append(resources, res)
+	}
+	return map[string]any{"resources": resources}
+}
+
+func (s *Server) readResource(params json.RawMessage) (any, error) {
+	var p struct {
+		URI string `json:"uri"`
+	}
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, err
+	}
+	idx := slices.IndexFunc(s.resources, func(r Resource) bool { return r.URI == p.URI })
+	if idx < 0 {
+		return nil, &rpcError{Code: codeResourceNotFound, Message: fmt.Sprintf("resource not found: %q", p.URI)}
+	}
+	r := s.resources[idx]
+	text, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"contents": []map[string]string{{"uri": r.URI, "mimeType": r.MimeType, "text": text}},
+	}, nil
+}
+
+func unmarshalParams(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
+
+// DecodeArguments strictly decodes tool arguments into v: unknown fields, wrong types and
+// trailing data are rejected with ErrInvalidArguments.
+func DecodeArguments(args json.RawMessage, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(args))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidArguments, err)
+	}
+	if dec.More() {
+		return fmt.Errorf("%w: unexpected data after arguments object", ErrInvalidArguments)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestServeProtocolErrors(t *testing.T) {
+	srv := NewServer("test", "1", WithTools(Tool{
+		Name:        "fail",
+		InputSchema: json.RawMessage(`{"type":"object"}`),
+		Handler: func(context.Context, json.RawMessage) (any, error) {
+			return nil, errors.New("upstream unavailable")
+		},
+	}))
+	script := strings.Join([]string{
+		`not json`,
+		`[{"jsonrpc":"2.0","id":1,"method":"ping"}]`,
+		`{"jsonrpc":"1.0","id":2,"method":"ping"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"nope"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"missing"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"fail"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":5}}`,
+		`{"jsonrpc":"2.0","id":"six","method":"ping"}`,
+	}, "\n")
+
+	var out strings.Builder
+	if err := srv.Serve(context.Background(), strings.NewReader(script), &out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,`,
+		`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch requests are not supported"}}`,
+		`{"jsonrpc":"2.0","id":2,"error":{"code":-32600,`,
+		`{"jsonrpc":"2.0","id":3,"error":{"code":-32601,"message":"method not found: nope"}}`,
+		`{"jsonrpc":"2.0","id":4,"error":{"code":-32602,"message":"unknown tool \"missing\""}}`,
+		`{"jsonrpc":"2.0","id":5,"result":{"content":[{"type":"text","text":"upstream unavailable"}],"isError":true}}`,
+		`{"jsonrpc":"2.0","id":"six","result":{}}`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d replies, want %d:\n%s", len(lines), len(want), out.String())
+	}
+	for i := range want {
+		if !strings.HasPrefix(lines[i], want[i]) {
+			t.Errorf("reply %d = %s\nwant prefix %s", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestDecodeArguments(t *testing.T) {
+	var args struct {
+		Limit int `json:"limit"`
+	}
+	for _, raw := range []string{`{"limit":"5"}`, `{"limit":5,"extra":1}`, `{"limit":5} {}`} {
+		if err := DecodeArguments(json.RawMessage(raw), &args); !errors.Is(err, ErrInvalidArguments) {
+			t.Errorf("DecodeArguments(%s) = %v, want ErrInvalidArguments", raw, err)
+		}
+	}
+	if err := DecodeArguments(json.RawMessage(`{"limit":5}`), &args); err != nil || args.Limit != 5 {
+		t.Errorf("DecodeArguments = %v, %+v", err, args)
+	}
+}
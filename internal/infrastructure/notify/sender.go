@@ -0,0 +1,178 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRetries = 3
+	DefaultTimeout = 10 * time.Second
+
+	// maxRetryAfter caps a server-advertised Retry-After delay.
+	maxRetryAfter = 30 * time.Second
+)
+
+type HTTPError struct {
+	Webhook    string
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("webhook %q failed: HTTP %d", e.Webhook, e.StatusCode)
+	}
+	return fmt.Sprintf("webhook %q failed: HTTP %d: %s", e.Webhook, e.StatusCode, e.Body)
+}
+
+// Sender posts rendered bodies to webhooks, retrying network errors, 429 and 5xx with exponential
+// backoff (1s, 2s, 4s, ...). Other 4xx responses are not retried.
+type Sender struct {
+	http      *http.Client
+	retries   int
+	backoff   time.Duration
+	userAgent string
+	dryRun    io.Writer
+}
+
+type Option func(*Sender)
+
+func WithHTTPClient(h *http.Client) Option {
+	return func(s *Sender) {
+		if h != nil {
+			s.http = h
+		}
+	}
+}
+
+// WithRetries sets how many times a failed request is retried (0 = single attempt).
+func WithRetries(n int) Option {
+	return func(s *Sender) {
+		if n >= 0 {
+			s.retries = n
+		}
+	}
+}
+
+// WithBackoff sets the delay before the first retry; it doubles on every further retry.
+func WithBackoff(d time.Duration) Option {
+	return func(s *Sender) {
+		if d >= 0 {
+			s.backoff = d
+		}
+	}
+}
+
+// WithDryRun makes Send write the request it would make to out instead of sending it.
+func WithDryRun(out io.Writer) Option {
+	return func(s *Sender) {
+		s.dryRun = out
+	}
+}
+
+func NewSender(opts ...Option) *Sender {
+	s := &Sender{
+		http:      &http.Client{Timeout: DefaultTimeout},
+		retries:   DefaultRetries,
+		backoff:   time.Second,
+		userAgent: "folio212",
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	return s
+}
+
+// Send delivers body to w and returns the number of attempts made (0 in dry-run mode).
+func (s *Sender) Send(ctx context.Context, w *Webhook, body []byte) (attempts int, err error) {
+	if s.dryRun != nil {
+		_, err := fmt.Fprintf(s.dryRun, "# %s: %s %s (%s)\n%s\n", w.Name, w.Method, w.RedactedURL(), w.ContentType, bytes.TrimRight(body, "\n"))
+		return 0, err
+	}
+	delay := s.backoff
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := s.do(ctx, w, body)
+		if err == nil {
+			return attempt + 1, nil
+		}
+		if retryAfter < 0 || attempt >= s.retries {
+			return attempt + 1, err
+		}
+
+		wait := delay
+		if retryAfter > 0 {
+			wait = min(retryAfter, maxRetryAfter)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt + 1, ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// do makes one request. retryAfter is negative when the failure is permanent, positive when the
+// server asked for a specific delay and zero otherwise.
+func (s *Sender) do(ctx context.Context, w *Webhook, body []byte) (retryAfter time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, w.Method, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return -1, fmt.Errorf("webhook %q: failed to create request: %w", w.Name, err)
+	}
+	req.Header.Set("Content-Type", w.ContentType)
+	req.Header.Set("User-Agent", s.userAgent)
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return -1, ctx.Err()
+		}
+		// The URL may carry a token; report the redacted form only.
+		return 0, fmt.Errorf("webhook %q: request to %s failed: %w", w.Name, w.RedactedURL(), unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		io.Copy(io.Discard, resp.Body)
+		return 0, nil
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<12))
+	httpErr := &HTTPError{Webhook: w.Name, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(b))}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if n, perr := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After"))); perr == nil && n > 0 {
+			return time.Duration(n) * time.Second, httpErr
+		}
+		return 0, httpErr
+	case resp.StatusCode >= 500:
+		return 0, httpErr
+	default:
+		return -1, httpErr
+	}
+}
+
+// unwrapURLError drops the *url.Error wrapper, whose message repeats the full URL.
+func unwrapURLError(err error) error {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return ue.Err
+	}
+	return err
+}
@@ -0,0 +1,199 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/config"
+)
+
+// recorder answers requests with the given statuses in turn (the last one repeats) and records them.
+type recorder struct {
+	statuses []int
+	header   http.Header
+
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rec.mu.Lock()
+	n := len(rec.requests)
+	rec.requests = append(rec.requests, r)
+	rec.bodies = append(rec.bodies, body)
+	rec.mu.Unlock()
+
+	for k, v := range rec.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.statuses[min(n, len(rec.statuses)-1)])
+}
+
+func (rec *recorder) count() int {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return len(rec.requests)
+}
+
+func testWebhook(t *testing.T, rec *recorder, template string) *Webhook {
+	t.Helper()
+	srv := httptest.NewServer(rec)
+	t.Cleanup(srv.Close)
+	w, err := NewWebhook(config.WebhookConfig{
+		Name:     "test",
+		URL:      srv.URL + "/hooks/secret-token",
+		Template: template,
+		Headers:  map[string]string{"X-Test": "1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func testNotification() *portfolio.Notification {
+	return &portfolio.Notification{
+		SchemaVersion: portfolio.SchemaVersion,
+		Event:         portfolio.EventViolations,
+		AccountID:     42,
+		Currency:      "EUR",
+		Summary:       portfolio.NotificationSummary{AccountTotal: 1234.5, HoldingsValue: 1000, FreeCash: 234.5, HoldingsCount: 3},
+		Violations:    []portfolio.Violation{{Rule: "max-free-cash", Message: "free cash 234.50 > 100.00"}},
+	}
+}
+
+func TestSendJSONPayload(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusNoContent}}
+	w := testWebhook(t, rec, "")
+	body, err := w.Render(testNotification())
+	if err != nil {
+		t.Fatal(err)
+	}
+	attempts, err := NewSender().Send(context.Background(), w, body)
+	if err != nil || attempts != 1 {
+		t.Fatalf("Send = %d, %v; want 1, nil", attempts, err)
+	}
+
+	r := rec.requests[0]
+	if r.Method != http.MethodPost || r.URL.Path != "/hooks/secret-token" {
+		t.Errorf("request = %s %s", r.Method, r.URL.Path)
+	}
+	if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if r.Header.Get("X-Test") != "1" || r.Header.Get("User-Agent") != "folio212" {
+		t.Errorf("headers = %v", r.Header)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(rec.bodies[0], &got); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"schemaVersion", "event", "report", "accountId", "currency", "summary", "violations", "topHoldings"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("payload has no %q: %s", key, rec.bodies[0])
+		}
+	}
+	if got["event"] != portfolio.EventViolations {
+		t.Errorf("event = %v", got["event"])
+	}
+}
+
+func TestSendSlackPayload(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusOK}}
+	w := testWebhook(t, rec, TemplateSlack)
+	body, err := w.Render(testNotification())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSender().Send(context.Background(), w, body); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(rec.bodies[0], &got); err != nil {
+		t.Fatalf("slack body is not JSON: %v: %s", err, rec.bodies[0])
+	}
+	if !strings.Contains(got.Text, "account 1234.50 EUR") || !strings.Contains(got.Text, "- max-free-cash: free cash 234.50 > 100.00") {
+		t.Errorf("text = %q", got.Text)
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		header       http.Header
+		wantAttempts int
+		wantStatus   int // 0 = success
+	}{
+		{name: "5xx then success", statuses: []int{500, 502, 200}, wantAttempts: 3},
+		{name: "429 then success", statuses: []int{429, 200}, wantAttempts: 2},
+		{name: "429 with Retry-After", statuses: []int{429, 200}, header: http.Header{"Retry-After": {"1"}}, wantAttempts: 2},
+		{name: "5xx until retries run out", statuses: []int{503}, wantAttempts: 4, wantStatus: 503},
+		{name: "4xx is not retried", statuses: []int{400, 200}, wantAttempts: 1, wantStatus: 400},
+		{name: "404 is not retried", statuses: []int{404, 200}, wantAttempts: 1, wantStatus: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{statuses: tt.statuses, header: tt.header}
+			w := testWebhook(t, rec, "")
+			s := NewSender(WithRetries(3), WithBackoff(time.Millisecond))
+
+			attempts, err := s.Send(context.Background(), w, []byte(`{}`))
+			if attempts != tt.wantAttempts || rec.count() != tt.wantAttempts {
+				t.Errorf("attempts = %d, server saw %d; want %d", attempts, rec.count(), tt.wantAttempts)
+			}
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("err = %v", err)
+				}
+				return
+			}
+			var httpErr *HTTPError
+			if !errors.As(err, &httpErr) || httpErr.StatusCode != tt.wantStatus {
+				t.Errorf("err = %v, want HTTP %d", err, tt.wantStatus)
+			}
+			if strings.Contains(err.Error(), "secret-token") {
+				t.Errorf("error leaks the webhook URL: %v", err)
+			}
+		})
+	}
+}
+
+func TestSendDryRunSendsNothing(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusOK}}
+	w := testWebhook(t, rec, TemplateNtfy)
+	body, err := w.Render(testNotification())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	attempts, err := NewSender(WithDryRun(&out)).Send(context.Background(), w, body)
+	if err != nil || attempts != 0 {
+		t.Fatalf("Send = %d, %v; want 0, nil", attempts, err)
+	}
+	if rec.count() != 0 {
+		t.Fatalf("dry run made %d request(s)", rec.count())
+	}
+	printed := out.String()
+	if !strings.HasPrefix(printed, "# test: POST http://127.0.0.1:") || strings.Contains(printed, "secret-token") {
+		t.Errorf("dry-run header = %q", printed)
+	}
+	if !strings.Contains(printed, "Rule violations (1):") {
+		t.Errorf("dry-run output has no body: %q", printed)
+	}
+}
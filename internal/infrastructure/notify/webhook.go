@@ -0,0 +1,200 @@
+// Package notify delivers portfolio notifications to webhooks (generic JSON, Slack, Discord, ntfy).
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/config"
+	"github.com/nezdemkovski/folio212/internal/shared/constants"
+)
+
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// Built-in body templates, selected with template: slack|discord|ntfy. Any other value is parsed as
+// a Go text/template; an empty template sends the JSON payload.
+const (
+	TemplateSlack   = "slack"
+	TemplateDiscord = "discord"
+	TemplateNtfy    = "ntfy"
+)
+
+type preset struct {
+	body        string
+	contentType string
+	headers     map[string]string
+}
+
+var presets = map[string]preset{
+	TemplateSlack:   {body: `{"text":{{ message . | json }}}`, contentType: "application/json"},
+	TemplateDiscord: {body: `{"content":{{ message . | json }}}`, contentType: "application/json"},
+	TemplateNtfy: {
+		body:        `{{ message . }}`,
+		contentType: "text/plain; charset=utf-8",
+		headers:     map[string]string{"Title": "folio212"},
+	},
+}
+
+// Webhook is a resolved webhook endpoint with its body template.
+type Webhook struct {
+	Name        string
+	URL         string
+	Method      string
+	ContentType string
+	Headers     map[string]string
+	Events      []string
+	tmpl        *template.Template // nil = JSON payload
+}
+
+// NewWebhook validates cfg, resolves url_env and parses the body template.
+func NewWebhook(cfg config.WebhookConfig) (*Webhook, error) {
+	name := strings.TrimSpace(cfg.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidWebhook)
+	}
+	rawURL := strings.TrimSpace(cfg.URL)
+	if env := strings.TrimSpace(cfg.URLEnv); env != "" {
+		rawURL = strings.TrimSpace(os.Getenv(env))
+		if rawURL == "" {
+			return nil, fmt.Errorf("%w %q: environment variable %s is not set", ErrInvalidWebhook, name, env)
+		}
+	}
+	if rawURL == "" {
+		return nil, fmt.Errorf("%w %q: url or url_env is required", ErrInvalidWebhook, name)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w %q: url must be an http(s) URL", ErrInvalidWebhook, name)
+	}
+	for _, e := range cfg.Events {
+		if e != portfolio.EventSummary && e != portfolio.EventViolations {
+			return nil, fmt.Errorf("%w %q: unknown event %q (valid: %s, %s)", ErrInvalidWebhook, name, e, portfolio.EventSummary, portfolio.EventViolations)
+		}
+	}
+
+	w := &Webhook{
+		Name:        name,
+		URL:         rawURL,
+		Method:      strings.ToUpper(strings.TrimSpace(cfg.Method)),
+		ContentType: "application/json",
+		Headers:     map[string]string{},
+		Events:      cfg.Events,
+	}
+	if w.Method == "" {
+		w.Method = "POST"
+	}
+
+	body := cfg.Template
+	if p, ok := presets[strings.ToLower(strings.TrimSpace(body))]; ok {
+		body = p.body
+		w.ContentType = p.contentType
+		for k, v := range p.headers {
+			w.Headers[k] = v
+		}
+	}
+	if strings.TrimSpace(body) != "" {
+		t, err := template.New(name).Funcs(templateFuncs).Parse(body)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: template: %w", ErrInvalidWebhook, name, err)
+		}
+		w.tmpl = t
+	}
+	if ct := strings.TrimSpace(cfg.ContentType); ct != "" {
+		w.ContentType = ct
+	}
+	for k, v := range cfg.Headers {
+		w.Headers[k] = v
+	}
+	return w, nil
+}
+
+// Wants reports whether the webhook subscribes to event (no filter = all events).
+func (w *Webhook) Wants(event string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
+
+// Render returns the request body for n.
+func (w *Webhook) Render(n *portfolio.Notification) ([]byte, error) {
+	if w.tmpl == nil {
+		return json.Marshal(n)
+	}
+	var buf bytes.Buffer
+	if err := w.tmpl.Execute(&buf, n); err != nil {
+		return nil, fmt.Errorf("webhook %q: template: %w", w.Name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// RedactedURL hides the path and query, which often carry the webhook secret.
+func (w *Webhook) RedactedURL() string {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return "<invalid url>"
+	}
+	if u.Path == "" || u.Path == "/" {
+		return u.Scheme + "://" + u.Host
+	}
+	return u.Scheme + "://" + u.Host + "/…"
+}
+
+var templateFuncs = template.FuncMap{
+	"message": Message,
+	"money":   func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) },
+	"signed":  signed,
+	"pct":     func(v float64) string { return signed(v) + "%" },
+	"join":    strings.Join,
+	"json": func(v any) (string, error) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false) // keep "P&L" readable in chat messages
+		err := enc.Encode(v)
+		return strings.TrimRight(buf.String(), "\n"), err
+	},
+}
+
+// Message is the human-readable text used by the chat templates (also available as {{ message . }}).
+func Message(n *portfolio.Notification) string {
+	var b strings.Builder
+	label := "folio212"
+	if n.Profile != "" && n.Profile != constants.DefaultProfileName {
+		label += " [" + n.Profile + "]"
+	}
+	s := n.Summary
+	fmt.Fprintf(&b, "%s: account %.2f %s", label, s.AccountTotal, n.Currency)
+	if dc := n.DailyChange; dc != nil {
+		fmt.Fprintf(&b, " (%s, %s%% since %s)", signed(dc.AccountTotal.Delta), signed(dc.ChangePct), sinceDate(dc.Since))
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "Holdings %.2f, P&L %s (%s%%), free cash %.2f, %d positions\n",
+		s.HoldingsValue, signed(s.HoldingsPnL), signed(s.HoldingsReturnPct), s.FreeCash, s.HoldingsCount)
+	if len(n.Violations) > 0 {
+		fmt.Fprintf(&b, "Rule violations (%d):\n", len(n.Violations))
+		for _, v := range n.Violations {
+			fmt.Fprintf(&b, "- %s: %s\n", v.Rule, v.Message)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func signed(v float64) string {
+	if v > 0 {
+		return "+" + strconv.FormatFloat(v, 'f', 2, 64)
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func sinceDate(rfc3339 string) string {
+	if len(rfc3339) >= 10 {
+		return rfc3339[:10]
+	}
+	return rfc3339
+}
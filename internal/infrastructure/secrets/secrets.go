@@ -24,6 +24,16 @@ const (
 	KeyTrading212APISecret = "t212-api-secret"
 )
 
+// ProfileKey scopes a secret key to a named profile, e.g. "t212-api-secret" for profile "isa"
+// becomes "t212-api-secret-isa" (env: FOLIO212_T212_API_SECRET_ISA). The default profile uses the
+// unscoped key so existing installs keep working.
+func ProfileKey(key, profile string) string {
+	if profile == "" || profile == constants.DefaultProfileName {
+		return key
+	}
+	return key + "-" + profile
+}
+
 // Source indicates where a secret was retrieved from.
 type Source string
 
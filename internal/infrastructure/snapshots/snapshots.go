@@ -0,0 +1,202 @@
+// Package snapshots persists portfolio reports in an append-only JSONL file under the config directory.
+// Each line is one Record; the file is never rewritten, so concurrent cron runs only ever append.
+package snapshots
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/config"
+	"github.com/nezdemkovski/folio212/internal/shared/constants"
+)
+
+// IDLatest selects the most recent snapshot in Get.
+const IDLatest = "latest"
+
+// maxLineBytes bounds a single snapshot line (outputs with raw payloads can be large).
+const maxLineBytes = 64 * 1024 * 1024
+
+var ErrNotFound = errors.New("snapshot not found")
+
+type Record struct {
+	ID        string            `json:"id"` // <accountId>-<UTC timestamp>[-n]
+	SavedAt   time.Time         `json:"savedAt"`
+	AccountID int64             `json:"accountId"`
+	Output    *portfolio.Output `json:"output"`
+}
+
+type Store struct {
+	path string
+}
+
+// Open returns the store at the default location (~/.folio212/snapshots.jsonl).
+func Open() (*Store, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(filepath.Join(dir, constants.SnapshotsFileName)), nil
+}
+
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Save appends output as a new record and returns it. IDs have one-second resolution, so a second
+// save for the same account within a second gets a numeric suffix (see NewID).
+func (s *Store) Save(output *portfolio.Output, savedAt time.Time) (*Record, error) {
+	if output == nil {
+		return nil, fmt.Errorf("output is required")
+	}
+	existing, err := s.List(output.Summary.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, r := range existing {
+		taken[r.ID] = true
+	}
+	rec := &Record{
+		ID:        uniqueID(NewID(output.Summary.AccountID, savedAt), taken),
+		SavedAt:   savedAt,
+		AccountID: output.Summary.AccountID,
+		Output:    output,
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	// Snapshots contain account balances; keep them private like the secrets file.
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return rec, nil
+}
+
+// List returns all records, oldest first. A missing file is an empty store.
+// If accountID is non-zero, only that account's records are returned. A record whose ID repeats an
+// earlier line's (two concurrent saves in the same second) is given the next free suffix, so IDs
+// returned by List are unique.
+func (s *Store) List(accountID int64) ([]Record, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	var out []Record
+	seen := map[string]bool{}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	line := 0
+	for sc.Scan() {
+		line++
+		b := sc.Bytes()
+		if len(strings.TrimSpace(string(b))) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(b, &rec); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid snapshot record: %w", s.path, line, err)
+		}
+		rec.ID = uniqueID(rec.ID, seen)
+		seen[rec.ID] = true
+		if accountID != 0 && rec.AccountID != accountID {
+			continue
+		}
+		out = append(out, rec)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].SavedAt.Before(out[j].SavedAt)
+	})
+	return out, nil
+}
+
+// Get returns the record with the given ID, or the most recent one for IDLatest.
+func (s *Store) Get(id string, accountID int64) (*Record, error) {
+	records, err := s.List(accountID)
+	if err != nil {
+		return nil, err
+	}
+	id = strings.TrimSpace(id)
+	if strings.EqualFold(id, IDLatest) {
+		if len(records) == 0 {
+			return nil, ErrNotFound
+		}
+		return &records[len(records)-1], nil
+	}
+	for i := range records {
+		if records[i].ID == id {
+			return &records[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// Valuations implements portfolio.ValuationSource using each snapshot's account total.
+func (s *Store) Valuations(_ context.Context, accountID int64) ([]portfolio.Valuation, error) {
+	records, err := s.List(accountID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]portfolio.Valuation, 0, len(records))
+	for _, r := range records {
+		if r.Output == nil {
+			continue
+		}
+		out = append(out, portfolio.Valuation{At: r.SavedAt, Value: r.Output.Summary.Derived.AccountTotal})
+	}
+	return out, nil
+}
+
+// NewID returns the base ID of a snapshot: <accountId>-<yyyymmddThhmmssZ>. Saves within the same
+// second are stored as <base>-2, <base>-3, ...
+func NewID(accountID int64, savedAt time.Time) string {
+	return fmt.Sprintf("%d-%s", accountID, savedAt.UTC().Format("20060102T150405Z"))
+}
+
+// uniqueID returns id, or id with the lowest numeric suffix not in taken.
+func uniqueID(id string, taken map[string]bool) string {
+	if !taken[id] {
+		return id
+	}
+	for n := 2; ; n++ {
+		if candidate := fmt.Sprintf("%s-%d", id, n); !taken[candidate] {
+			return candidate
+		}
+	}
+}
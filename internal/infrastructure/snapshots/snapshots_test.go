@@ -0,0 +1,82 @@
+package snapshots
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+func testOutput(accountID int64, total float64) *portfolio.Output {
+	out := &portfolio.Output{SchemaVersion: portfolio.SchemaVersion}
+	out.Summary.AccountID = accountID
+	out.Summary.Derived.AccountTotal = total
+	return out
+}
+
+func TestSaveSameSecondGetsDistinctIDs(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "snapshots.jsonl"))
+	at := time.Date(2025, 3, 1, 22, 0, 0, 0, time.UTC)
+
+	first, err := store.Save(testOutput(7, 100), at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := store.Save(testOutput(7, 200), at.Add(400*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := store.Save(testOutput(8, 300), at)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.ID != "7-20250301T220000Z" || second.ID != "7-20250301T220000Z-2" || other.ID != "8-20250301T220000Z" {
+		t.Fatalf("IDs = %q, %q, %q", first.ID, second.ID, other.ID)
+	}
+	for id, want := range map[string]float64{first.ID: 100, second.ID: 200} {
+		rec, err := store.Get(id, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := rec.Output.Summary.Derived.AccountTotal; got != want {
+			t.Errorf("Get(%q) total = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestListDisambiguatesDuplicateLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshots.jsonl")
+	at := time.Date(2025, 3, 1, 22, 0, 0, 0, time.UTC)
+	// Two processes that both computed the same ID before either appended.
+	var data []byte
+	for _, total := range []float64{100, 200} {
+		b, err := json.Marshal(Record{ID: NewID(7, at), SavedAt: at, AccountID: 7, Output: testOutput(7, total)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(append(data, b...), '\n')
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewStore(path)
+	records, err := store.List(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].ID == records[1].ID {
+		t.Fatalf("records = %+v", records)
+	}
+	rec, err := store.Get(NewID(7, at)+"-2", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Output.Summary.Derived.AccountTotal != 200 {
+		t.Errorf("second line total = %v, want 200", rec.Output.Summary.Derived.AccountTotal)
+	}
+}
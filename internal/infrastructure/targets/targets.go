@@ -0,0 +1,104 @@
+// Package targets reads target allocation weights from a YAML file under the config directory.
+package targets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/config"
+	"github.com/nezdemkovski/folio212/internal/shared/constants"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrNotFound = errors.New("targets file not found")
+
+// File is the on-disk format. Weights are percentages of holdings value.
+//
+//	targets:
+//	  - ticker: VWCEd_EQ
+//	    weight: 60
+//	groups:
+//	  - name: bonds
+//	    weight: 40
+//	    members:
+//	      - isin: IE00B4WXJJ64
+//	      - ticker: IGLTl_EQ
+type File struct {
+	Targets []Entry `yaml:"targets"`
+	Groups  []Group `yaml:"groups,omitempty"`
+}
+
+type Entry struct {
+	Ticker string  `yaml:"ticker,omitempty"`
+	ISIN   string  `yaml:"isin,omitempty"`
+	Weight float64 `yaml:"weight,omitempty"` // for group members: share of the group (default: equal split)
+}
+
+type Group struct {
+	Name    string  `yaml:"name"`
+	Weight  float64 `yaml:"weight"`
+	Members []Entry `yaml:"members"`
+}
+
+// DefaultPath returns the targets file for a profile: targets-<profile>.yaml when it exists for a
+// named profile, otherwise targets.yaml.
+func DefaultPath(profile string) (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if profile != "" && profile != constants.DefaultProfileName {
+		ext := filepath.Ext(constants.TargetsFileName)
+		p := filepath.Join(dir, strings.TrimSuffix(constants.TargetsFileName, ext)+"-"+profile+ext)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return filepath.Join(dir, constants.TargetsFileName), nil
+}
+
+// Load reads and parses a targets file.
+func Load(path string) (portfolio.Targets, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return portfolio.Targets{}, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return portfolio.Targets{}, fmt.Errorf("failed to read targets file: %w", err)
+	}
+
+	var f File
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil {
+		return portfolio.Targets{}, fmt.Errorf("%w: %s: %v", portfolio.ErrInvalidTargets, path, err)
+	}
+	return f.toDomain(), nil
+}
+
+func (f File) toDomain() portfolio.Targets {
+	var t portfolio.Targets
+	for _, e := range f.Targets {
+		t.Holdings = append(t.Holdings, e.toDomain())
+	}
+	for _, g := range f.Groups {
+		group := portfolio.TargetGroup{Name: strings.TrimSpace(g.Name), WeightPct: g.Weight}
+		for _, m := range g.Members {
+			group.Members = append(group.Members, m.toDomain())
+		}
+		t.Groups = append(t.Groups, group)
+	}
+	return t
+}
+
+func (e Entry) toDomain() portfolio.Target {
+	return portfolio.Target{
+		Ticker:    strings.TrimSpace(e.Ticker),
+		ISIN:      strings.ToUpper(strings.TrimSpace(e.ISIN)),
+		WeightPct: e.Weight,
+	}
+}
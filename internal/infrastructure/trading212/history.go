@@ -0,0 +1,130 @@
+package trading212
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// historyPageLimit is the maximum page size accepted by the history endpoints.
+const historyPageLimit = 50
+
+// maxPageRetries bounds how many times a single page is retried after a 429.
+const maxPageRetries = 3
+
+// GetHistoricalOrders returns historical orders (newest first), following nextPagePath until exhausted.
+// If ticker is non-empty, the API filters to that ticker. If since is non-zero, paging stops after a
+// page on which every order was both created and filled before since (older orders may still be
+// present in the result). Pages are ordered by creation time, so an order created earlier but
+// filled after since keeps paging going.
+func (c *Client) GetHistoricalOrders(ctx context.Context, ticker string, since time.Time) ([]HistoricalOrder, error) {
+	q := url.Values{}
+	q.Set("limit", strconv.Itoa(historyPageLimit))
+	if strings.TrimSpace(ticker) != "" {
+		q.Set("ticker", strings.TrimSpace(ticker))
+	}
+
+	return collectPages(ctx, c, "/api/v0/equity/history/orders", q, func(page []HistoricalOrder) bool {
+		if since.IsZero() || len(page) == 0 {
+			return true
+		}
+		for _, o := range page {
+			if !o.LastActivity().Before(since) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// GetDividends returns paid-out dividends (newest first), following nextPagePath until exhausted.
+// If ticker is non-empty, the API filters to that ticker. If since is non-zero, paging stops once a
+// page contains a dividend paid before since.
+func (c *Client) GetDividends(ctx context.Context, ticker string, since time.Time) ([]HistoryDividendItem, error) {
+	q := url.Values{}
+	q.Set("limit", strconv.Itoa(historyPageLimit))
+	if strings.TrimSpace(ticker) != "" {
+		q.Set("ticker", strings.TrimSpace(ticker))
+	}
+
+	return collectPages(ctx, c, "/api/v0/history/dividends", q, func(page []HistoryDividendItem) bool {
+		if since.IsZero() || len(page) == 0 {
+			return true
+		}
+		return !page[len(page)-1].PaidOn.Before(since)
+	})
+}
+
+// GetTransactions returns account cash transactions (deposits, withdrawals, fees, ...), newest first,
+// following nextPagePath until exhausted. If since is non-zero, paging stops once a page contains a
+// transaction older than since.
+func (c *Client) GetTransactions(ctx context.Context, since time.Time) ([]HistoryTransactionItem, error) {
+	q := url.Values{}
+	q.Set("limit", strconv.Itoa(historyPageLimit))
+
+	return collectPages(ctx, c, "/api/v0/history/transactions", q, func(page []HistoryTransactionItem) bool {
+		if since.IsZero() || len(page) == 0 {
+			return true
+		}
+		return !page[len(page)-1].DateTime.Before(since)
+	})
+}
+
+// collectPages fetches path and every page referenced by nextPagePath, concatenating items.
+// more is called after each page; returning false stops paging early.
+// History endpoints have tight per-minute limits, so a 429 on a page waits and retries that page.
+func collectPages[T any](ctx context.Context, c *Client, path string, query url.Values, more func(page []T) bool) ([]T, error) {
+	var out []T
+	for {
+		var page Page[T]
+		if err := c.getPage(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+		out = append(out, page.Items...)
+
+		if more != nil && !more(page.Items) {
+			return out, nil
+		}
+		if page.NextPagePath == nil || strings.TrimSpace(*page.NextPagePath) == "" {
+			return out, nil
+		}
+
+		next, err := url.Parse(strings.TrimSpace(*page.NextPagePath))
+		if err != nil {
+			return nil, fmt.Errorf("invalid nextPagePath %q: %w", *page.NextPagePath, err)
+		}
+		path = next.Path
+		query = next.Query()
+	}
+}
+
+func (c *Client) getPage(ctx context.Context, path string, query url.Values, out any) error {
+	for attempt := 0; ; attempt++ {
+		err := c.doJSON(ctx, http.MethodGet, path, query, out)
+		if err == nil {
+			return nil
+		}
+
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests || attempt >= maxPageRetries {
+			return err
+		}
+
+		d, ok := httpErr.SuggestedRetryDelay(time.Now())
+		if !ok || d <= 0 {
+			d = 10 * time.Second
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
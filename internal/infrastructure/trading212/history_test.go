@@ -0,0 +1,163 @@
+package trading212
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pagedServer serves pages[i] for the i-th request, linking each page to the next via nextPagePath,
+// and records the requested paths and cursors.
+type pagedServer struct {
+	t     *testing.T
+	path  string
+	pages [][]any
+
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func (s *pagedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	n := len(s.requests)
+	s.requests = append(s.requests, r)
+	s.mu.Unlock()
+
+	if r.URL.Path != s.path {
+		s.t.Errorf("request %d: path = %q, want %q", n, r.URL.Path, s.path)
+		http.NotFound(w, r)
+		return
+	}
+	if n >= len(s.pages) {
+		s.t.Errorf("unexpected request %d for %s", n, r.URL)
+		http.NotFound(w, r)
+		return
+	}
+	body := map[string]any{"items": s.pages[n], "nextPagePath": nil}
+	if n+1 < len(s.pages) {
+		body["nextPagePath"] = s.path + "?limit=50&cursor=page" + string(rune('1'+n))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (s *pagedServer) client(t *testing.T) *Client {
+	t.Helper()
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	c, err := NewClient(srv.URL, "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func order(id int64, created, filled string) map[string]any {
+	o := map[string]any{
+		"order": map[string]any{"id": id, "createdAt": created, "status": "FILLED", "ticker": "AAPL_US_EQ"},
+	}
+	if filled != "" {
+		o["fill"] = map[string]any{"id": id, "filledAt": filled}
+	}
+	return o
+}
+
+func TestGetHistoricalOrdersFollowsPages(t *testing.T) {
+	s := &pagedServer{t: t, path: "/api/v0/equity/history/orders", pages: [][]any{
+		{order(3, "2025-03-01T10:00:00Z", "2025-03-01T10:00:01Z")},
+		{order(2, "2025-02-01T10:00:00Z", "2025-02-01T10:00:01Z")},
+		{order(1, "2025-01-01T10:00:00Z", "")},
+	}}
+	orders, err := s.client(t).GetHistoricalOrders(context.Background(), "AAPL_US_EQ", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 3 {
+		t.Fatalf("got %d orders, want 3", len(orders))
+	}
+	if got := s.requests[0].URL.Query(); got.Get("limit") != "50" || got.Get("ticker") != "AAPL_US_EQ" {
+		t.Errorf("first page query = %v", got)
+	}
+	for i, cursor := range []string{"page1", "page2"} {
+		if got := s.requests[i+1].URL.Query().Get("cursor"); got != cursor {
+			t.Errorf("page %d cursor = %q, want %q", i+2, got, cursor)
+		}
+	}
+}
+
+func TestGetHistoricalOrdersKeepsPagingForLateFills(t *testing.T) {
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	s := &pagedServer{t: t, path: "/api/v0/equity/history/orders", pages: [][]any{
+		{
+			order(4, "2025-03-05T10:00:00Z", "2025-03-05T10:00:01Z"),
+			// Limit order placed before the period and filled inside it: the last item on the page.
+			order(3, "2025-02-20T10:00:00Z", "2025-03-02T14:30:00Z"),
+		},
+		{
+			order(2, "2025-02-10T10:00:00Z", "2025-03-03T09:00:00Z"),
+			order(1, "2025-02-01T10:00:00Z", "2025-02-01T10:00:01Z"),
+		},
+		{order(0, "2025-01-01T10:00:00Z", "2025-01-01T10:00:01Z")},
+		{order(-1, "2024-12-01T10:00:00Z", "2024-12-01T10:00:01Z")},
+	}}
+	orders, err := s.client(t).GetHistoricalOrders(context.Background(), "", since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Page 3 is fetched because page 2 has a fill inside the period; page 3 has none, so paging stops.
+	if len(s.requests) != 3 {
+		t.Fatalf("fetched %d pages, want 3", len(s.requests))
+	}
+	var inPeriod []int64
+	for _, o := range orders {
+		if o.Fill != nil && !o.Fill.FilledAt.Before(since) {
+			inPeriod = append(inPeriod, o.Order.ID)
+		}
+	}
+	if len(inPeriod) != 3 || inPeriod[0] != 4 || inPeriod[1] != 3 || inPeriod[2] != 2 {
+		t.Errorf("fills in period = %v, want [4 3 2]", inPeriod)
+	}
+}
+
+func TestGetDividendsPathAndPaging(t *testing.T) {
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	s := &pagedServer{t: t, path: "/api/v0/history/dividends", pages: [][]any{
+		{map[string]any{"ticker": "AAPL_US_EQ", "amount": 1.5, "paidOn": "2025-03-10T00:00:00Z"}},
+		{map[string]any{"ticker": "MSFT_US_EQ", "amount": 2.0, "paidOn": "2025-02-10T00:00:00Z"}},
+		{map[string]any{"ticker": "AAPL_US_EQ", "amount": 1.4, "paidOn": "2024-12-10T00:00:00Z"}},
+	}}
+	divs, err := s.client(t).GetDividends(context.Background(), "", since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.requests) != 2 || len(divs) != 2 {
+		t.Fatalf("fetched %d pages and %d dividends, want 2 and 2", len(s.requests), len(divs))
+	}
+	if got := s.requests[1].URL.Query().Get("cursor"); got != "page1" {
+		t.Errorf("second page cursor = %q, want page1", got)
+	}
+}
+
+func TestGetTransactionsPathAndPaging(t *testing.T) {
+	s := &pagedServer{t: t, path: "/api/v0/history/transactions", pages: [][]any{
+		{map[string]any{"type": "DEPOSIT", "amount": 100.0, "dateTime": "2025-03-10T00:00:00Z", "reference": "a"}},
+		{map[string]any{"type": "WITHDRAW", "amount": -40.0, "dateTime": "2025-02-10T00:00:00Z", "reference": "b"}},
+	}}
+	txs, err := s.client(t).GetTransactions(context.Background(), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.requests) != 2 || len(txs) != 2 {
+		t.Fatalf("fetched %d pages and %d transactions, want 2 and 2", len(s.requests), len(txs))
+	}
+	if got := s.requests[1].URL.Query().Get("cursor"); got != "page1" {
+		t.Errorf("second page cursor = %q, want page1", got)
+	}
+	if txs[1].Type != "WITHDRAW" || txs[1].Amount != -40 {
+		t.Errorf("second transaction = %+v", txs[1])
+	}
+}
@@ -74,3 +74,104 @@ type TradableInstrument struct {
 	Type              string    `json:"type"` // e.g. "ETF", "STOCK"
 	WorkingScheduleID int64     `json:"workingScheduleId"`
 }
+
+// Page is a single page of a paginated history endpoint.
+// NextPagePath is nil (or empty) on the last page.
+type Page[T any] struct {
+	Items        []T     `json:"items"`
+	NextPagePath *string `json:"nextPagePath"`
+}
+
+type HistoricalOrder struct {
+	Fill  *Fill `json:"fill,omitempty"`
+	Order Order `json:"order"`
+}
+
+// LastActivity is when the order was filled, or created if it has no fill.
+func (o HistoricalOrder) LastActivity() time.Time {
+	if o.Fill != nil && o.Fill.FilledAt.After(o.Order.CreatedAt) {
+		return o.Fill.FilledAt
+	}
+	return o.Order.CreatedAt
+}
+
+type Order struct {
+	CreatedAt      time.Time  `json:"createdAt"`
+	Currency       string     `json:"currency"`
+	ExtendedHours  bool       `json:"extendedHours"`
+	FilledQuantity float64    `json:"filledQuantity"`
+	FilledValue    *float64   `json:"filledValue,omitempty"`
+	ID             int64      `json:"id"`
+	InitiatedFrom  string     `json:"initiatedFrom"`
+	Instrument     Instrument `json:"instrument"`
+	LimitPrice     *float64   `json:"limitPrice,omitempty"`
+	Quantity       float64    `json:"quantity"`
+	Side           string     `json:"side"`   // "BUY" or "SELL"
+	Status         string     `json:"status"` // e.g. "FILLED", "CANCELLED"
+	StopPrice      *float64   `json:"stopPrice,omitempty"`
+	Strategy       string     `json:"strategy"`
+	Ticker         string     `json:"ticker"`
+	TimeInForce    string     `json:"timeInForce"`
+	Type           string     `json:"type"` // e.g. "MARKET", "LIMIT"
+	Value          *float64   `json:"value,omitempty"`
+}
+
+type Fill struct {
+	FilledAt      time.Time        `json:"filledAt"`
+	ID            int64            `json:"id"`
+	Price         float64          `json:"price"`
+	Quantity      float64          `json:"quantity"`
+	TradingMethod string           `json:"tradingMethod"`
+	Type          string           `json:"type"`
+	WalletImpact  FillWalletImpact `json:"walletImpact"`
+}
+
+type FillWalletImpact struct {
+	Currency           string   `json:"currency"`
+	FXRate             *float64 `json:"fxRate,omitempty"`
+	NetValue           float64  `json:"netValue"`
+	RealisedProfitLoss *float64 `json:"realisedProfitLoss,omitempty"`
+	Taxes              []Tax    `json:"taxes"`
+}
+
+// Fees is the sum of all taxes and charges applied to the fill (absolute, in the account currency).
+func (w FillWalletImpact) Fees() float64 {
+	var sum float64
+	for _, t := range w.Taxes {
+		if t.Quantity < 0 {
+			sum -= t.Quantity
+		} else {
+			sum += t.Quantity
+		}
+	}
+	return sum
+}
+
+type Tax struct {
+	ChargedAt time.Time `json:"chargedAt"`
+	Currency  string    `json:"currency"`
+	Name      string    `json:"name"`
+	Quantity  float64   `json:"quantity"`
+}
+
+type HistoryDividendItem struct {
+	Amount              float64     `json:"amount"` // net amount in account currency
+	AmountInEuro        float64     `json:"amountInEuro"`
+	Currency            string      `json:"currency"`
+	GrossAmountPerShare float64     `json:"grossAmountPerShare"` // in ticker currency
+	Instrument          *Instrument `json:"instrument,omitempty"`
+	PaidOn              time.Time   `json:"paidOn"`
+	Quantity            float64     `json:"quantity"`
+	Reference           string      `json:"reference"`
+	Ticker              string      `json:"ticker"`
+	TickerCurrency      string      `json:"tickerCurrency"`
+	Type                string      `json:"type"` // e.g. "ORDINARY", "RETURN_OF_CAPITAL"
+}
+
+type HistoryTransactionItem struct {
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency"`
+	DateTime  time.Time `json:"dateTime"`
+	Reference string    `json:"reference"`
+	Type      string    `json:"type"` // e.g. "DEPOSIT", "WITHDRAW", "FEE", "TRANSFER"
+}
@@ -0,0 +1,26 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+// RenderCheckText prints one line per violation, or a single OK line.
+func RenderCheckText(c *portfolio.CheckOutput, w io.Writer) error {
+	var s strings.Builder
+
+	if c.OK {
+		s.WriteString(fmt.Sprintf("OK: %d rule(s) passed (%s)\n", len(c.Rules), strings.Join(c.Rules, ", ")))
+	} else {
+		s.WriteString(fmt.Sprintf("FAIL: %d violation(s)\n", len(c.Violations)))
+		for _, v := range c.Violations {
+			s.WriteString(fmt.Sprintf("  [%s] %s\n", v.Rule, v.Message))
+		}
+	}
+
+	_, err := w.Write([]byte(s.String()))
+	return err
+}
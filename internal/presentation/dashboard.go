@@ -0,0 +1,417 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/shared/ui"
+)
+
+// DashboardMinRefresh is the shortest interval between two refreshes. Trading212 rate limits
+// the portfolio endpoints per account, so manual refreshes inside this window are deferred.
+const DashboardMinRefresh = 10 * time.Second
+
+// DashboardFetchFunc loads a fresh portfolio report.
+type DashboardFetchFunc func(ctx context.Context) (*portfolio.Output, error)
+
+type dashboardLoadedMsg struct {
+	output *portfolio.Output
+	err    error
+	at     time.Time
+}
+
+type dashboardTickMsg struct{}
+
+type DashboardModel struct {
+	fetch   DashboardFetchFunc
+	timeout time.Duration
+
+	output     *portfolio.Output
+	holdings   []portfolio.HoldingRow // sorted copy of output.Holdings
+	err        error
+	loading    bool
+	lastFetch  time.Time
+	refreshDue bool // a refresh was requested inside the rate-limit window
+
+	sortKey  portfolio.HoldingSortKey
+	reverse  bool
+	selected int
+	offset   int
+
+	width  int
+	height int
+}
+
+// NewDashboardModel creates the interactive dashboard. fetch is called once on start and on
+// every refresh with a context bounded by timeout.
+func NewDashboardModel(fetch DashboardFetchFunc, timeout time.Duration) *DashboardModel {
+	return &DashboardModel{
+		fetch:   fetch,
+		timeout: timeout,
+		sortKey: portfolio.SortByValue,
+		loading: true,
+	}
+}
+
+func (m *DashboardModel) Init() tea.Cmd {
+	return m.load()
+}
+
+func (m *DashboardModel) load() tea.Cmd {
+	fetch, timeout := m.fetch, m.timeout
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		output, err := fetch(ctx)
+		return dashboardLoadedMsg{output: output, err: err, at: time.Now()}
+	}
+}
+
+func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.clampSelection()
+
+	case dashboardLoadedMsg:
+		m.loading = false
+		m.lastFetch = msg.at
+		if msg.err != nil {
+			// Keep showing the previous data; the error is shown in the status line.
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.output = msg.output
+		m.resort()
+
+	case dashboardTickMsg:
+		if m.refreshDue && !m.loading {
+			return m, m.refresh()
+		}
+
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			m.selected--
+			m.clampSelection()
+		case "down", "j":
+			m.selected++
+			m.clampSelection()
+		case "pgup":
+			m.selected -= m.tableRows()
+			m.clampSelection()
+		case "pgdown":
+			m.selected += m.tableRows()
+			m.clampSelection()
+		case "home", "g":
+			m.selected = 0
+			m.clampSelection()
+		case "end", "G":
+			m.selected = len(m.holdings) - 1
+			m.clampSelection()
+		case "s":
+			m.sortKey = nextSortKey(m.sortKey)
+			m.resort()
+		case "o":
+			m.reverse = !m.reverse
+			m.resort()
+		case "r":
+			if m.loading {
+				return m, nil
+			}
+			return m, m.refresh()
+		}
+	}
+	return m, nil
+}
+
+// refresh starts a fetch now, or schedules one for when the rate-limit window has passed.
+func (m *DashboardModel) refresh() tea.Cmd {
+	if wait := time.Until(m.lastFetch.Add(DashboardMinRefresh)); wait > 0 {
+		if m.refreshDue {
+			return nil // already scheduled
+		}
+		m.refreshDue = true
+		return tea.Tick(wait, func(time.Time) tea.Msg { return dashboardTickMsg{} })
+	}
+	m.refreshDue = false
+	m.loading = true
+	return m.load()
+}
+
+// resort re-sorts holdings and keeps the selected ticker selected when it still exists.
+func (m *DashboardModel) resort() {
+	var ticker string
+	if m.selected >= 0 && m.selected < len(m.holdings) {
+		ticker = m.holdings[m.selected].Ticker
+	}
+	m.holdings = nil
+	if m.output != nil {
+		m.holdings = append([]portfolio.HoldingRow(nil), m.output.Holdings...)
+	}
+	portfolio.SortHoldings(m.holdings, m.sortKey, m.reverse)
+
+	m.selected = 0
+	for i, h := range m.holdings {
+		if h.Ticker == ticker {
+			m.selected = i
+			break
+		}
+	}
+	m.clampSelection()
+}
+
+func (m *DashboardModel) clampSelection() {
+	m.selected = max(0, min(m.selected, len(m.holdings)-1))
+	rows := m.tableRows()
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+	if m.selected >= m.offset+rows {
+		m.offset = m.selected - rows + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.holdings)-rows))
+}
+
+const (
+	dashboardHeaderLines  = 6 // title, 3 metric lines, status, blank
+	dashboardDetailsLines = 9 // blank, title, 6 detail lines, blank
+	dashboardFooterLines  = 1
+)
+
+// tableRows is the number of holdings visible at once (excluding the column header).
+func (m *DashboardModel) tableRows() int {
+	if m.height == 0 {
+		return 10
+	}
+	return max(3, m.height-dashboardHeaderLines-dashboardDetailsLines-dashboardFooterLines-1)
+}
+
+func (m *DashboardModel) View() tea.View {
+	if m.width == 0 || m.height == 0 {
+		v := tea.NewView("Loading...")
+		v.AltScreen = true
+		return v
+	}
+
+	var body string
+	switch {
+	case m.output == nil && m.err != nil:
+		body = ui.StatusError(HumanizeAccountError(m.err).Error()) + "\n\n" + m.renderFooter()
+	case m.output == nil:
+		body = ui.StatusRunning("Loading portfolio...")
+	default:
+		body = lipgloss.JoinVertical(lipgloss.Left,
+			m.renderHeader(),
+			m.renderTable(),
+			m.renderDetails(),
+			m.renderFooter(),
+		)
+	}
+
+	v := tea.NewView(lipgloss.NewStyle().Padding(0, 1).Render(body))
+	v.AltScreen = true
+	return v
+}
+
+func (m *DashboardModel) renderHeader() string {
+	d := m.output.Summary.Derived
+	cur := currencyLabel(m.output.Summary.Currency)
+	var s strings.Builder
+
+	title := ui.Title.Render("folio212") + " " + ui.Meta.Render("portfolio")
+	if m.output.Summary.AccountID != 0 {
+		title += " " + ui.Meta.Render(fmt.Sprintf("· account %d", m.output.Summary.AccountID))
+	}
+	s.WriteString(title + "\n")
+
+	s.WriteString(metricCell("total", fmt.Sprintf("%.2f %s", d.AccountTotal, cur)) +
+		metricCell("holdings", fmt.Sprintf("%.2f", d.HoldingsValue)) +
+		metricCell("pie cash", fmt.Sprintf("%.2f", d.PieCash)) +
+		metricCell("free cash", fmt.Sprintf("%.2f", d.FreeCash)) + "\n")
+	s.WriteString(metricCell("cost", fmt.Sprintf("%.2f", d.HoldingsCost)) +
+		ui.Label.Render("pnl ") + signedStyle(d.HoldingsPnL).Render(fmt.Sprintf("%+.2f", d.HoldingsPnL)) + "   " +
+		ui.Label.Render("return ") + signedStyle(d.HoldingsReturnPct).Render(fmt.Sprintf("%+.2f%%", d.HoldingsReturnPct)) + "\n")
+
+	perf := ui.Label.Render("twr ") + signedStyle(d.TWRPctEst).Render(fmt.Sprintf("%+.2f%%", d.TWRPctEst)) +
+		" " + ui.Meta.Render("("+d.TWRMethod+")")
+	if d.MWRPct != nil {
+		perf += "   " + ui.Label.Render("mwr ") + signedStyle(*d.MWRPct).Render(fmt.Sprintf("%+.2f%%", *d.MWRPct))
+	}
+	s.WriteString(perf + "\n")
+
+	s.WriteString(m.renderStatus() + "\n")
+	return s.String()
+}
+
+func (m *DashboardModel) renderStatus() string {
+	sortBy := string(m.sortKey)
+	if m.reverse {
+		sortBy += " (reversed)"
+	}
+	status := ui.Meta.Render(fmt.Sprintf("%d holdings · sort %s · updated %s",
+		len(m.holdings), sortBy, m.lastFetch.Format("15:04:05")))
+
+	switch {
+	case m.loading:
+		status += "  " + ui.StatusRunning("refreshing...")
+	case m.refreshDue:
+		wait := time.Until(m.lastFetch.Add(DashboardMinRefresh)).Round(time.Second)
+		status += "  " + ui.StatusWarning(fmt.Sprintf("rate limit: refreshing in %s", max(wait, 0)))
+	case m.err != nil:
+		status += "  " + ui.StatusError(HumanizeAccountError(m.err).Error())
+	}
+	return status
+}
+
+func (m *DashboardModel) renderTable() string {
+	var s strings.Builder
+	header := fmt.Sprintf("  %-12s %-24s %12s %14s %14s %9s %8s",
+		"ticker", "name", "qty", "value", "pnl", "return", "weight")
+	s.WriteString(ui.Label.Render(header) + "\n")
+
+	if len(m.holdings) == 0 {
+		s.WriteString(ui.Meta.Render("  No holdings."))
+		return s.String()
+	}
+
+	end := min(len(m.holdings), m.offset+m.tableRows())
+	for i := m.offset; i < end; i++ {
+		h := m.holdings[i]
+		ret := portfolio.HoldingReturnPct(h)
+		cursor := "  "
+		if i == m.selected {
+			cursor = ui.ActiveStyle.Render(ui.SymbolActive) + " "
+		}
+		left := fmt.Sprintf("%-12s %-24s %12s %14.2f ",
+			truncate(h.Ticker, 12), truncate(h.Name, 24), formatQty(h.Qty), h.MarketValue)
+		if i == m.selected {
+			left = ui.ActiveStyle.Render(left)
+		}
+		s.WriteString(cursor + left +
+			signedStyle(h.UnrealizedPnL).Render(fmt.Sprintf("%14.2f", h.UnrealizedPnL)) + " " +
+			signedStyle(ret).Render(fmt.Sprintf("%8.2f%%", ret)) + " " +
+			fmt.Sprintf("%7.2f%%", h.HoldingsPct) + "\n")
+	}
+	if len(m.holdings) > m.tableRows() {
+		s.WriteString(ui.Meta.Render(fmt.Sprintf("  %d-%d of %d", m.offset+1, end, len(m.holdings))) + "\n")
+	}
+	return strings.TrimSuffix(s.String(), "\n")
+}
+
+func (m *DashboardModel) renderDetails() string {
+	if len(m.holdings) == 0 {
+		return ""
+	}
+	h := m.holdings[m.selected]
+	var s strings.Builder
+
+	title := h.Ticker
+	if h.Name != "" {
+		title += " · " + h.Name
+	}
+	s.WriteString("\n" + ui.SectionHeader(title) + "\n")
+
+	s.WriteString(detailLine(
+		"isin", orDash(h.ISIN),
+		"opened", orDash(dateOnly(h.OpenedAt))))
+	s.WriteString(detailLine(
+		"qty", formatQty(h.Qty),
+		"tradable", formatQty(h.TradableQty)+" (in pies "+formatQty(h.QtyInPies)+")"))
+	s.WriteString(detailLine(
+		"avg price", fmt.Sprintf("%.4f %s", h.AvgPricePaid, h.InstrumentCurrency),
+		"current", fmt.Sprintf("%.4f %s", h.CurrentPrice, h.InstrumentCurrency)))
+	s.WriteString(detailLine(
+		"invested", fmt.Sprintf("%.2f %s", h.Invested, h.AccountCurrency),
+		"value", fmt.Sprintf("%.2f %s", h.MarketValue, h.AccountCurrency)))
+
+	fx := "-"
+	if h.FXImpact != nil {
+		fx = fmt.Sprintf("%+.2f", *h.FXImpact)
+		if h.FXPair != "" {
+			fx += " (" + h.FXPair + ")"
+		}
+	}
+	s.WriteString(detailLine(
+		"pnl", fmt.Sprintf("%+.2f (%+.2f%%)", h.UnrealizedPnL, portfolio.HoldingReturnPct(h)),
+		"fx impact", fx))
+
+	mwr := "-"
+	if h.MWRPct != nil {
+		mwr = fmt.Sprintf("%+.2f%%", *h.MWRPct)
+	}
+	s.WriteString(detailLine(
+		"weight", fmt.Sprintf("%.2f%% (%d bps)", h.HoldingsPct, h.HoldingsBps),
+		"mwr", mwr))
+	return s.String()
+}
+
+func (m *DashboardModel) renderFooter() string {
+	return ui.Meta.Render("↑/↓ select · s sort · o reverse · r refresh · q quit")
+}
+
+// Error returns the last fetch error when the dashboard never loaded any data.
+func (m *DashboardModel) Error() error {
+	if m.output == nil {
+		return m.err
+	}
+	return nil
+}
+
+func nextSortKey(k portfolio.HoldingSortKey) portfolio.HoldingSortKey {
+	keys := portfolio.HoldingSortKeys
+	for i, key := range keys {
+		if key == k {
+			return keys[(i+1)%len(keys)]
+		}
+	}
+	return keys[0]
+}
+
+func metricCell(label, value string) string {
+	return ui.Label.Render(label+" ") + ui.Value.Render(value) + "   "
+}
+
+func detailLine(l1, v1, l2, v2 string) string {
+	return fmt.Sprintf("  %s %s %s %s\n",
+		ui.Label.Render(fmt.Sprintf("%-10s", l1)), ui.Value.Render(fmt.Sprintf("%-28s", v1)),
+		ui.Label.Render(fmt.Sprintf("%-10s", l2)), ui.Value.Render(v2))
+}
+
+func signedStyle(v float64) lipgloss.Style {
+	switch {
+	case v > 0:
+		return ui.SuccessStyle
+	case v < 0:
+		return ui.ErrorStyle
+	}
+	return ui.Value
+}
+
+func formatQty(q float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.6f", q), "0"), ".")
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+func orDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
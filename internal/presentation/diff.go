@@ -0,0 +1,83 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+// RenderDiffText prints total deltas, the top biggest movers, then opened/closed positions.
+// top <= 0 lists every changed holding.
+func RenderDiffText(d *portfolio.DiffOutput, top int, w io.Writer) error {
+	var s strings.Builder
+
+	s.WriteString(fmt.Sprintf("From: %s (%s)\n", d.From.Label, d.From.GeneratedAt))
+	s.WriteString(fmt.Sprintf("To:   %s (%s)\n", d.To.Label, d.To.GeneratedAt))
+	for _, warning := range d.Warnings {
+		s.WriteString(fmt.Sprintf("WARNING: %s\n", warning))
+	}
+	s.WriteString("\n")
+
+	s.WriteString(fmt.Sprintf("Totals (%s)\n", d.Currency))
+	writeDelta(&s, "account total", d.Totals.AccountTotal)
+	writeDelta(&s, "holdings value", d.Totals.HoldingsValue)
+	writeDelta(&s, "cost basis", d.Totals.HoldingsCost)
+	writeDelta(&s, "uPnL", d.Totals.HoldingsPnL)
+	writeDelta(&s, "free cash", d.Totals.FreeCash)
+	writeDelta(&s, "pie cash", d.Totals.PieCash)
+	s.WriteString(fmt.Sprintf("  %-16s %+d bps (%.2f%% -> %.2f%%)\n", "return:", d.Totals.HoldingsReturnBps.Delta,
+		float64(d.Totals.HoldingsReturnBps.Before)/100, float64(d.Totals.HoldingsReturnBps.After)/100))
+	s.WriteString("\n")
+
+	var changed, opened, closed []portfolio.HoldingChange
+	for _, h := range d.Holdings {
+		switch h.Change {
+		case portfolio.ChangeNew:
+			opened = append(opened, h)
+		case portfolio.ChangeClosed:
+			closed = append(closed, h)
+		case portfolio.ChangeChanged:
+			changed = append(changed, h)
+		}
+	}
+
+	s.WriteString("Biggest movers (by market value change)\n")
+	if len(changed) == 0 {
+		s.WriteString("  none\n")
+	}
+	for i, h := range changed {
+		if top > 0 && i >= top {
+			s.WriteString(fmt.Sprintf("  ... %d more (use --top 0 to show all)\n", len(changed)-top))
+			break
+		}
+		s.WriteString(fmt.Sprintf("  %-12s value %+10.2f  uPnL %+10.2f  qty %+.6g  weight %+d bps\n",
+			h.Ticker, h.MarketValue.Delta, h.UnrealizedPnL.Delta, h.Qty.Delta, h.Weight.Delta))
+	}
+	s.WriteString("\n")
+
+	if len(opened) > 0 {
+		s.WriteString("New positions\n")
+		for _, h := range opened {
+			s.WriteString(fmt.Sprintf("  %-12s %s: qty %.6g, value %.2f (%.2f%% of holdings)\n",
+				h.Ticker, h.Name, h.Qty.After, h.MarketValue.After, float64(h.Weight.After)/100))
+		}
+		s.WriteString("\n")
+	}
+	if len(closed) > 0 {
+		s.WriteString("Closed positions\n")
+		for _, h := range closed {
+			s.WriteString(fmt.Sprintf("  %-12s %s: was qty %.6g, value %.2f\n",
+				h.Ticker, h.Name, h.Qty.Before, h.MarketValue.Before))
+		}
+		s.WriteString("\n")
+	}
+
+	_, err := w.Write([]byte(s.String()))
+	return err
+}
+
+func writeDelta(s *strings.Builder, label string, d portfolio.Delta) {
+	s.WriteString(fmt.Sprintf("  %-16s %+.2f (%.2f -> %.2f)\n", label+":", d.Delta, d.Before, d.After))
+}
@@ -0,0 +1,46 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+func RenderDividendsText(output *portfolio.DividendsOutput, w io.Writer) error {
+	var s strings.Builder
+
+	s.WriteString(fmt.Sprintf("Report date: %s\n", output.Report.ReportDate))
+	s.WriteString(fmt.Sprintf("Reporting period: %s\n", formatPeriodLabel(output.Report.Period)))
+	if output.Ticker != "" {
+		s.WriteString(fmt.Sprintf("Ticker: %s\n", output.Ticker))
+	}
+	s.WriteString("\n")
+
+	s.WriteString(fmt.Sprintf("Dividends (%s)\n", currencyLabel(output.Currency)))
+	s.WriteString(fmt.Sprintf("  payments: %d\n", output.Totals.Count))
+	s.WriteString(fmt.Sprintf("  net received: %.2f\n", output.Totals.Net))
+	s.WriteString(fmt.Sprintf("  gross: %s\n", formatOptionalMoney(output.Totals.Gross)))
+	s.WriteString(fmt.Sprintf("  withholding: %s\n\n", formatOptionalMoney(output.Totals.Withholding)))
+
+	if len(output.Groups) == 0 {
+		s.WriteString("No dividends in this period.\n")
+	} else {
+		s.WriteString(fmt.Sprintf("By %s:\n", output.GroupBy))
+		for _, g := range output.Groups {
+			s.WriteString(fmt.Sprintf("  %-12s %10.2f  (%d payments, gross: %s, withholding: %s)\n",
+				g.Key, g.Net, g.Count, formatOptionalMoney(g.Gross), formatOptionalMoney(g.Withholding)))
+		}
+	}
+
+	_, err := w.Write([]byte(s.String()))
+	return err
+}
+
+func formatOptionalMoney(v *float64) string {
+	if v == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.2f", *v)
+}
@@ -32,6 +32,29 @@ func HumanizePortfolioError(err error) error {
 	return err
 }
 
+func HumanizeHistoryError(err error) error {
+	var httpErr *trading212.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == 403 {
+		return fmt.Errorf("%w (missing permission: enable \"History\" for your Trading212 API key)", err)
+	}
+	if errors.As(err, &httpErr) && httpErr.StatusCode == 429 {
+		if d, ok := httpErr.SuggestedRetryDelay(time.Now()); ok {
+			secs := max(int(d.Round(time.Second).Seconds()), 1)
+			return fmt.Errorf("%w (rate limited: history endpoints allow ~6 requests/min; try again in ~%ds)", err, secs)
+		}
+		return fmt.Errorf("%w (rate limited: history endpoints allow ~6 requests/min; try again in a minute)", err)
+	}
+	return err
+}
+
+// HumanizeFXError explains how to supply a rate the consolidated report could not derive.
+func HumanizeFXError(err error) error {
+	if errors.Is(err, portfolio.ErrMissingFXRate) {
+		return fmt.Errorf("%w (no position links these currencies; pass --fx CCY=RATE, e.g. --fx USD=0.92)", err)
+	}
+	return err
+}
+
 func HumanizeDomainError(err error) string {
 	switch {
 	case errors.Is(err, portfolio.ErrConfigNotLoaded):
@@ -0,0 +1,156 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+// holdingJSONKeys maps holding field names (the CSV header) to their JSON keys.
+var holdingJSONKeys = map[string]string{
+	"ticker":              "ticker",
+	"name":                "name",
+	"isin":                "isin",
+	"opened_at":           "openedAt",
+	"qty":                 "qty",
+	"tradable_qty":        "tradableQty",
+	"qty_in_pies":         "qtyInPies",
+	"instrument_currency": "instrumentCurrency",
+	"avg_price_paid":      "avgPricePaid",
+	"current_price":       "currentPrice",
+	"account_currency":    "accountCurrency",
+	"invested":            "invested",
+	"market_value":        "marketValue",
+	"unrealized_pnl":      "unrealizedPnL",
+	"fx_impact":           "fxImpact",
+	"fx_pair":             "fxPair",
+	"holdings_pct":        "holdingsPct",
+	"holdings_bps":        "holdingsBps",
+	"mwr_pct":             "mwrPct",
+}
+
+// holdingFieldAliases are the short names also used by --sort and --filter.
+var holdingFieldAliases = map[string]string{
+	"value":     "market_value",
+	"pnl":       "unrealized_pnl",
+	"pct":       "holdings_pct",
+	"currency":  "instrument_currency",
+	"price":     "current_price",
+	"avg_price": "avg_price_paid",
+	"opened":    "opened_at",
+	"mwr":       "mwr_pct",
+}
+
+// ParseHoldingFields resolves --fields names (CSV header names, JSON keys or short aliases) to
+// holding field names in the requested order.
+func ParseHoldingFields(names []string) ([]string, error) {
+	var out []string
+	for _, raw := range names {
+		for _, name := range strings.Split(raw, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			field, ok := resolveHoldingField(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown field %q (expected one of: %s)", name, strings.Join(HoldingFieldNames(), ", "))
+			}
+			if !slices.Contains(out, field) {
+				out = append(out, field)
+			}
+		}
+	}
+	return out, nil
+}
+
+// HoldingFieldNames lists the holding field names in their default (CSV header) order.
+func HoldingFieldNames() []string {
+	names := make([]string, len(holdingCSVColumns))
+	for i, c := range holdingCSVColumns {
+		names[i] = c.name
+	}
+	return names
+}
+
+func resolveHoldingField(name string) (string, bool) {
+	lower := strings.ToLower(name)
+	if _, ok := holdingJSONKeys[lower]; ok {
+		return lower, true
+	}
+	if f, ok := holdingFieldAliases[lower]; ok {
+		return f, true
+	}
+	for field, key := range holdingJSONKeys {
+		if strings.EqualFold(key, name) {
+			return field, true
+		}
+	}
+	return "", false
+}
+
+// selectHoldingColumns returns the columns for fields, in the given order (all columns when empty).
+func selectHoldingColumns(fields []string) []csvColumn[portfolio.HoldingRow] {
+	if len(fields) == 0 {
+		return holdingCSVColumns
+	}
+	cols := make([]csvColumn[portfolio.HoldingRow], 0, len(fields))
+	for _, f := range fields {
+		for _, c := range holdingCSVColumns {
+			if c.name == f {
+				cols = append(cols, c)
+			}
+		}
+	}
+	return cols
+}
+
+// RenderPortfolioJSON writes the report as one JSON object. With fields, every holding only
+// carries those keys (in that order; missing optional values are null).
+func RenderPortfolioJSON(output *portfolio.Output, fields []string, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	if len(fields) == 0 {
+		return enc.Encode(output)
+	}
+
+	holdings := make([]json.RawMessage, 0, len(output.Holdings))
+	for _, h := range output.Holdings {
+		b, err := json.Marshal(h)
+		if err != nil {
+			return err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(b, &all); err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, f := range fields {
+			key := holdingJSONKeys[f]
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			k, _ := json.Marshal(key)
+			buf.Write(k)
+			buf.WriteByte(':')
+			if v, ok := all[key]; ok {
+				buf.Write(v)
+			} else {
+				buf.WriteString("null")
+			}
+		}
+		buf.WriteByte('}')
+		holdings = append(holdings, buf.Bytes())
+	}
+
+	// The outer Holdings shadows the embedded one.
+	return enc.Encode(struct {
+		*portfolio.Output
+		Holdings []json.RawMessage `json:"holdings"`
+	}{output, holdings})
+}
@@ -0,0 +1,139 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/shared/ui"
+)
+
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// RenderHistoryChart draws one labelled sparkline per series, at most width cells wide.
+func RenderHistoryChart(h *portfolio.HistoryOutput, width int, w io.Writer) error {
+	var s strings.Builder
+
+	if isHistoryEmpty(h) {
+		s.WriteString(ui.StatusInfo("No snapshots in this period. Run 'folio212 snapshot save' to record history."))
+		s.WriteString("\n")
+		_, err := w.Write([]byte(s.String()))
+		return err
+	}
+
+	width = max(width, 10)
+	for _, series := range h.Series {
+		values := make([]float64, len(series.Points))
+		for i, p := range series.Points {
+			values[i] = p.Value
+		}
+		// min and max come from the snapshots themselves; resampling averages extremes away.
+		lo, hi := minMax(values)
+		values = resample(values, width)
+
+		first, last := series.Points[0].Value, series.Points[len(series.Points)-1].Value
+
+		title := series.Label
+		if series.Ticker != "" {
+			title = series.Ticker + " " + title
+		}
+		s.WriteString(ui.SectionHeader(title))
+		if series.Metric != portfolio.MetricQty {
+			s.WriteString(" " + ui.Meta.Render(fmt.Sprintf("(%s)", currencyLabel(h.Currency))))
+		}
+		s.WriteString("\n")
+
+		lineStyle := ui.SuccessStyle
+		if last < first {
+			lineStyle = ui.ErrorStyle
+		}
+		s.WriteString("  " + lineStyle.Render(sparkline(values, lo, hi)))
+		s.WriteString("\n")
+
+		s.WriteString("  " + ui.Meta.Render(fmt.Sprintf("%s -> %s", dateOnly(series.Points[0].At), dateOnly(series.Points[len(series.Points)-1].At))))
+		s.WriteString("\n")
+		s.WriteString("  " + ui.Label.Render("first ") + ui.Value.Render(fmt.Sprintf("%.2f", first)) +
+			ui.Label.Render("  last ") + ui.Value.Render(fmt.Sprintf("%.2f", last)) +
+			ui.Label.Render("  change ") + lineStyle.Render(fmt.Sprintf("%+.2f", last-first)) +
+			ui.Label.Render("  min ") + ui.Value.Render(fmt.Sprintf("%.2f", lo)) +
+			ui.Label.Render("  max ") + ui.Value.Render(fmt.Sprintf("%.2f", hi)))
+		s.WriteString("\n\n")
+	}
+
+	_, err := w.Write([]byte(s.String()))
+	return err
+}
+
+// RenderHistoryTable prints one row per snapshot with a column per series (for pipes and files).
+func RenderHistoryTable(h *portfolio.HistoryOutput, w io.Writer) error {
+	var s strings.Builder
+
+	if isHistoryEmpty(h) {
+		s.WriteString("No snapshots in this period.\n")
+		_, err := w.Write([]byte(s.String()))
+		return err
+	}
+
+	s.WriteString(fmt.Sprintf("%-25s", "time"))
+	for _, series := range h.Series {
+		s.WriteString(fmt.Sprintf(" %16s", series.Label))
+	}
+	s.WriteString("\n")
+
+	for i, p := range h.Series[0].Points {
+		s.WriteString(fmt.Sprintf("%-25s", p.At))
+		for _, series := range h.Series {
+			s.WriteString(fmt.Sprintf(" %16.2f", series.Points[i].Value))
+		}
+		s.WriteString("\n")
+	}
+
+	_, err := w.Write([]byte(s.String()))
+	return err
+}
+
+func isHistoryEmpty(h *portfolio.HistoryOutput) bool {
+	return len(h.Series) == 0 || len(h.Series[0].Points) == 0
+}
+
+func sparkline(values []float64, lo, hi float64) string {
+	var b strings.Builder
+	span := hi - lo
+	for _, v := range values {
+		idx := len(sparkLevels) / 2
+		if span > 0 {
+			idx = int(math.Round((v - lo) / span * float64(len(sparkLevels)-1)))
+		}
+		b.WriteRune(sparkLevels[idx])
+	}
+	return b.String()
+}
+
+// resample reduces values to at most n points by averaging consecutive buckets.
+func resample(values []float64, n int) []float64 {
+	if len(values) <= n {
+		return values
+	}
+	out := make([]float64, n)
+	for i := range n {
+		start := i * len(values) / n
+		end := max((i+1)*len(values)/n, start+1)
+		var sum float64
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		out[i] = sum / float64(end-start)
+	}
+	return out
+}
+
+func minMax(values []float64) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return lo, hi
+}
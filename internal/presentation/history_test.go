@@ -0,0 +1,50 @@
+package presentation
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func TestRenderHistoryChart(t *testing.T) {
+	// 40 points in a 10-cell chart: the one-off spike is averaged away in the sparkline but must
+	// still be the reported max.
+	var total, qty []portfolio.HistoryPoint
+	for i := range 40 {
+		at := time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC).AddDate(0, 0, i).Format(time.RFC3339)
+		value := 1000.0
+		if i == 17 {
+			value = 5000
+		}
+		total = append(total, portfolio.HistoryPoint{At: at, Value: value})
+		qty = append(qty, portfolio.HistoryPoint{At: at, Value: 3})
+	}
+	h := &portfolio.HistoryOutput{
+		Currency: "EUR",
+		Series: []portfolio.HistorySeries{
+			{Metric: portfolio.MetricHoldingsValue, Label: "holdings value", Ticker: "AAPL_US_EQ", Points: total},
+			{Metric: portfolio.MetricQty, Label: "quantity", Ticker: "AAPL_US_EQ", Points: qty},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderHistoryChart(h, 10, &buf); err != nil {
+		t.Fatal(err)
+	}
+	out := ansiEscape.ReplaceAllString(buf.String(), "")
+	if !strings.Contains(out, "max 5000.00") || !strings.Contains(out, "min 1000.00") {
+		t.Errorf("min/max are not the snapshot extremes:\n%s", out)
+	}
+	if !strings.Contains(out, "holdings value (EUR)") {
+		t.Errorf("money series has no currency label:\n%s", out)
+	}
+	if strings.Contains(out, "quantity (EUR)") {
+		t.Errorf("quantity series is labelled with a currency:\n%s", out)
+	}
+}
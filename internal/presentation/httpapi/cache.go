@@ -0,0 +1,123 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+)
+
+// MinPollInterval is the hard floor between Trading212 refreshes: the account summary endpoint
+// allows one request per 5s.
+const MinPollInterval = 5 * time.Second
+
+// DefaultPollInterval is the default minimum time between refresh attempts, failed ones included.
+const DefaultPollInterval = 10 * time.Second
+
+// FetchFunc loads a fresh report.
+type FetchFunc func(ctx context.Context) (*portfolio.Output, error)
+
+// Entry is a cached report. Stale is set when the last refresh failed and an older report is
+// served instead.
+type Entry struct {
+	Output    *portfolio.Output
+	FetchedAt time.Time
+	Stale     bool
+}
+
+// Cache shares one report between all requests, refreshing it on demand. At most one fetch runs
+// at a time; a report is reused for the TTL and a failed refresh is not retried before the
+// minimum poll interval (or the server's Retry-After on 429), whatever the request rate.
+type Cache struct {
+	fetch       FetchFunc
+	ttl         time.Duration
+	minInterval time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	output    *portfolio.Output
+	fetchedAt time.Time
+	err       error
+	nextFetch time.Time
+}
+
+type CacheOption func(*Cache)
+
+// WithMinPollInterval sets the minimum time between refresh attempts (at least MinPollInterval).
+func WithMinPollInterval(d time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.minInterval = d
+	}
+}
+
+// NewCache returns a cache that reuses a report for ttl. The TTL is raised to the minimum poll
+// interval when shorter.
+func NewCache(fetch FetchFunc, ttl time.Duration, opts ...CacheOption) *Cache {
+	c := &Cache{fetch: fetch, minInterval: DefaultPollInterval, now: time.Now}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	c.minInterval = max(c.minInterval, MinPollInterval)
+	c.ttl = max(ttl, c.minInterval)
+	return c
+}
+
+// TTL is the effective refresh interval for a successfully fetched report.
+func (c *Cache) TTL() time.Duration {
+	return c.ttl
+}
+
+// Get returns the cached report, refreshing it first when it is older than the TTL. The
+// returned Output is shared and must not be modified.
+func (c *Cache) Get(ctx context.Context) (Entry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Before(c.nextFetch) {
+		return c.entry()
+	}
+
+	output, err := c.fetch(ctx)
+	now = c.now()
+	if err != nil {
+		c.nextFetch = now.Add(c.minInterval)
+		if errors.Is(err, context.Canceled) {
+			c.nextFetch = time.Time{} // the caller went away; let the next request try again
+		}
+		var httpErr *trading212.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == 429 {
+			if d, ok := httpErr.SuggestedRetryDelay(now); ok && d > c.minInterval {
+				c.nextFetch = now.Add(d)
+			}
+		}
+		c.err = err
+		return c.entry()
+	}
+	c.output, c.fetchedAt, c.err = output, now, nil
+	c.nextFetch = now.Add(c.ttl)
+	return c.entry()
+}
+
+// entry reports the current state; callers hold mu.
+func (c *Cache) entry() (Entry, error) {
+	if c.output == nil {
+		if c.err == nil {
+			return Entry{}, errors.New("no report loaded yet")
+		}
+		return Entry{}, c.err
+	}
+	return Entry{Output: c.output, FetchedAt: c.fetchedAt, Stale: c.err != nil}, nil
+}
+
+// RetryAfter is how long until the next refresh is allowed.
+func (c *Cache) RetryAfter() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return max(c.nextFetch.Sub(c.now()), 0)
+}
@@ -0,0 +1,137 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+)
+
+// fakeFetch returns the queued results in order and counts calls.
+type fakeFetch struct {
+	results []error // nil = success
+	calls   int
+}
+
+func (f *fakeFetch) fetch(context.Context) (*portfolio.Output, error) {
+	f.calls++
+	var err error
+	if len(f.results) > 0 {
+		err, f.results = f.results[0], f.results[1:]
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &portfolio.Output{Summary: portfolio.Summary{AccountID: int64(f.calls)}}, nil
+}
+
+// fakeClock is a settable time source for Cache.now.
+type fakeClock struct{ t time.Time }
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestCache(f *fakeFetch, clock *fakeClock) *Cache {
+	c := NewCache(f.fetch, time.Minute)
+	c.now = clock.now
+	return c
+}
+
+func TestCacheReusesReportForTTL(t *testing.T) {
+	f := &fakeFetch{}
+	clock := newFakeClock()
+	c := newTestCache(f, clock)
+
+	for _, step := range []struct {
+		advance   time.Duration
+		wantCalls int
+	}{
+		{0, 1},
+		{30 * time.Second, 1},
+		{29 * time.Second, 1},
+		{time.Second, 2},
+	} {
+		clock.advance(step.advance)
+		entry, err := c.Get(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.calls != step.wantCalls {
+			t.Errorf("after %s: %d fetches, want %d", step.advance, f.calls, step.wantCalls)
+		}
+		// The fake numbers its reports, so the latest fetch must be the one served.
+		if entry.Stale || entry.Output.Summary.AccountID != int64(f.calls) {
+			t.Errorf("after %s: entry = %+v", step.advance, entry)
+		}
+	}
+}
+
+func TestCacheTTLIsAtLeastPollInterval(t *testing.T) {
+	if got := NewCache(nil, time.Second).TTL(); got != DefaultPollInterval {
+		t.Errorf("TTL = %s, want %s", got, DefaultPollInterval)
+	}
+	if got := NewCache(nil, time.Second, WithMinPollInterval(time.Second)).TTL(); got != MinPollInterval {
+		t.Errorf("TTL with a 1s poll interval = %s, want %s", got, MinPollInterval)
+	}
+}
+
+func TestCacheServesStaleReportAfterFailure(t *testing.T) {
+	f := &fakeFetch{results: []error{nil, errors.New("boom"), errors.New("boom")}}
+	clock := newFakeClock()
+	c := newTestCache(f, clock)
+
+	first, err := c.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.advance(time.Minute)
+	entry, err := c.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !entry.Stale || entry.Output != first.Output || !entry.FetchedAt.Equal(first.FetchedAt) {
+		t.Errorf("after a failed refresh: entry = %+v, want the first report marked stale", entry)
+	}
+
+	// A failed refresh is not retried before the minimum poll interval.
+	clock.advance(DefaultPollInterval - time.Second)
+	if _, err := c.Get(context.Background()); err != nil || f.calls != 2 {
+		t.Errorf("within the poll interval: %d fetches (err %v), want 2", f.calls, err)
+	}
+	if got := c.RetryAfter(); got != time.Second {
+		t.Errorf("RetryAfter = %s, want 1s", got)
+	}
+	clock.advance(time.Second)
+	if _, err := c.Get(context.Background()); err != nil || f.calls != 3 {
+		t.Errorf("after the poll interval: %d fetches (err %v), want 3", f.calls, err)
+	}
+}
+
+func TestCacheHonoursRetryAfter(t *testing.T) {
+	rateLimited := &trading212.HTTPError{StatusCode: 429, RetryAfterSeconds: 120}
+	f := &fakeFetch{results: []error{rateLimited}}
+	clock := newFakeClock()
+	c := newTestCache(f, clock)
+
+	if _, err := c.Get(context.Background()); !errors.Is(err, rateLimited) {
+		t.Fatalf("err = %v, want the 429 without a cached report", err)
+	}
+	if got := c.RetryAfter(); got != 2*time.Minute {
+		t.Errorf("RetryAfter = %s, want 2m", got)
+	}
+	clock.advance(time.Minute)
+	if _, err := c.Get(context.Background()); !errors.Is(err, rateLimited) || f.calls != 1 {
+		t.Errorf("before Retry-After: %d fetches (err %v), want 1", f.calls, err)
+	}
+	clock.advance(time.Minute)
+	if _, err := c.Get(context.Background()); err != nil || f.calls != 2 {
+		t.Errorf("after Retry-After: %d fetches (err %v), want 2", f.calls, err)
+	}
+}
@@ -0,0 +1,294 @@
+// Package httpapi serves portfolio reports and stored snapshots as a read-only JSON REST API,
+// plus Prometheus metrics.
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/snapshots"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+	"github.com/nezdemkovski/folio212/internal/presentation"
+)
+
+// Header names set on report responses.
+const (
+	HeaderFetchedAt = "X-Folio212-Fetched-At" // RFC3339 time the report was fetched from Trading212
+	HeaderStale     = "X-Folio212-Stale"      // "true" when the last refresh failed
+)
+
+type handler struct {
+	cache     *Cache
+	store     *snapshots.Store
+	accountID int64 // account whose snapshots are served
+	token     string
+	mux       *http.ServeMux
+}
+
+type Option func(*handler)
+
+// WithToken requires "Authorization: Bearer <token>" on every endpoint except /healthz.
+func WithToken(token string) Option {
+	return func(h *handler) {
+		h.token = token
+	}
+}
+
+// WithSnapshots enables the /api/v1/snapshots endpoints for one account's stored snapshots.
+func WithSnapshots(store *snapshots.Store, accountID int64) Option {
+	return func(h *handler) {
+		h.store = store
+		h.accountID = accountID
+	}
+}
+
+// NewHandler returns the API:
+//
+//	GET /healthz
+//	GET /metrics
+//	GET /api/v1/portfolio
+//	GET /api/v1/holdings[?sort=&reverse=&filter=&top=]
+//	GET /api/v1/holdings/{ticker}
+//	GET /api/v1/allocation
+//	GET /api/v1/snapshots
+//	GET /api/v1/snapshots/{id}
+func NewHandler(cache *Cache, opts ...Option) http.Handler {
+	h := &handler{cache: cache, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	h.mux.HandleFunc("GET /healthz", h.health)
+	h.mux.HandleFunc("GET /metrics", h.metrics)
+	h.mux.HandleFunc("GET /api/v1/portfolio", h.portfolio)
+	h.mux.HandleFunc("GET /api/v1/holdings", h.holdings)
+	h.mux.HandleFunc("GET /api/v1/holdings/{ticker}", h.holding)
+	h.mux.HandleFunc("GET /api/v1/allocation", h.allocation)
+	if h.store != nil {
+		h.mux.HandleFunc("GET /api/v1/snapshots", h.snapshots)
+		h.mux.HandleFunc("GET /api/v1/snapshots/{id}", h.snapshot)
+	}
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.token != "" && r.URL.Path != "/healthz" && !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="folio212"`)
+		writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *handler) authorized(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	scheme, token, ok := strings.Cut(auth, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(h.token)) == 1
+}
+
+func (h *handler) health(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// metrics refreshes the report when it is older than the cache TTL, so the scrape interval drives
+// polling. Failures are reported as folio212_up 0 rather than an HTTP error.
+func (h *handler) metrics(w http.ResponseWriter, r *http.Request) {
+	var output *portfolio.Output
+	var opts presentation.MetricsOptions
+	if entry, err := h.cache.Get(r.Context()); err == nil {
+		output = entry.Output
+		opts = presentation.MetricsOptions{FetchedAt: entry.FetchedAt, Stale: entry.Stale}
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = presentation.RenderPortfolioMetrics(output, opts, w)
+}
+
+// report returns the cached report, or writes the error response and returns nil.
+func (h *handler) report(w http.ResponseWriter, r *http.Request) *portfolio.Output {
+	entry, err := h.cache.Get(r.Context())
+	if err != nil {
+		status := http.StatusBadGateway
+		var httpErr *trading212.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests {
+			status = http.StatusServiceUnavailable
+			w.Header().Set("Retry-After", strconv.Itoa(max(int(h.cache.RetryAfter().Seconds()), 1)))
+		}
+		writeError(w, status, presentation.HumanizeAccountError(err).Error())
+		return nil
+	}
+	w.Header().Set(HeaderFetchedAt, entry.FetchedAt.UTC().Format(time.RFC3339))
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(h.cache.TTL().Seconds())))
+	if entry.Stale {
+		w.Header().Set(HeaderStale, "true")
+	}
+	return entry.Output
+}
+
+func (h *handler) portfolio(w http.ResponseWriter, r *http.Request) {
+	if output := h.report(w, r); output != nil {
+		writeJSON(w, http.StatusOK, output)
+	}
+}
+
+type holdingsResponse struct {
+	SchemaVersion int                    `json:"schemaVersion"`
+	Report        portfolio.Report       `json:"report"`
+	Currency      string                 `json:"currency"`
+	Holdings      []portfolio.HoldingRow `json:"holdings"`
+}
+
+type allocationResponse struct {
+	SchemaVersion int                       `json:"schemaVersion"`
+	Report        portfolio.Report          `json:"report"`
+	Currency      string                    `json:"currency"`
+	Allocation    []portfolio.AllocationRow `json:"allocation"`
+}
+
+func (h *handler) holdings(w http.ResponseWriter, r *http.Request) {
+	sel, err := parseSelection(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	output := h.report(w, r)
+	if output == nil {
+		return
+	}
+	// Apply replaces the slices it changes, so a shallow copy keeps the cached report intact.
+	selected := *output
+	sel.Apply(&selected)
+	writeJSON(w, http.StatusOK, holdingsResponse{
+		SchemaVersion: selected.SchemaVersion,
+		Report:        selected.Report,
+		Currency:      selected.Summary.Currency,
+		Holdings:      selected.Holdings,
+	})
+}
+
+func (h *handler) holding(w http.ResponseWriter, r *http.Request) {
+	ticker := r.PathValue("ticker")
+	output := h.report(w, r)
+	if output == nil {
+		return
+	}
+	for _, row := range output.Holdings {
+		if strings.EqualFold(row.Ticker, ticker) || row.ISIN == ticker {
+			writeJSON(w, http.StatusOK, row)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("no open position for %s", ticker))
+}
+
+func (h *handler) allocation(w http.ResponseWriter, r *http.Request) {
+	if output := h.report(w, r); output != nil {
+		writeJSON(w, http.StatusOK, allocationResponse{
+			SchemaVersion: output.SchemaVersion,
+			Report:        output.Report,
+			Currency:      output.Summary.Currency,
+			Allocation:    output.Allocation,
+		})
+	}
+}
+
+func (h *handler) snapshots(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAccount(w, r) {
+		return
+	}
+	records, err := h.store.List(h.accountID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	summaries := make([]presentation.SnapshotSummary, 0, len(records))
+	for _, rec := range records {
+		summaries = append(summaries, presentation.NewSnapshotSummary(rec))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"snapshots": summaries})
+}
+
+func (h *handler) snapshot(w http.ResponseWriter, r *http.Request) {
+	if !h.checkAccount(w, r) {
+		return
+	}
+	rec, err := h.store.Get(r.PathValue("id"), h.accountID)
+	if errors.Is(err, snapshots.ErrNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, rec)
+}
+
+// parseSelection reads the same sort, reverse, filter and top options as 'folio212 portfolio'.
+func parseSelection(r *http.Request) (portfolio.HoldingSelection, error) {
+	q := r.URL.Query()
+	var sel portfolio.HoldingSelection
+	key, err := portfolio.ParseHoldingSortKey(q.Get("sort"))
+	if err != nil {
+		return sel, fmt.Errorf("invalid sort: %w", err)
+	}
+	sel.Sort = key
+	if v := q.Get("reverse"); v != "" {
+		if sel.Reverse, err = strconv.ParseBool(v); err != nil {
+			return sel, fmt.Errorf("invalid reverse %q: expected true or false", v)
+		}
+	}
+	if v := q.Get("top"); v != "" {
+		if sel.Top, err = strconv.Atoi(v); err != nil || sel.Top < 0 {
+			return sel, fmt.Errorf("invalid top %q: expected an integer >= 0", v)
+		}
+	}
+	for _, expr := range q["filter"] {
+		f, err := portfolio.ParseHoldingFilter(expr)
+		if err != nil {
+			return sel, err
+		}
+		sel.Filters = append(sel.Filters, f)
+	}
+	return sel, nil
+}
+
+// checkAccount rejects an ?account= other than the served account, or writes nothing and returns
+// true. Snapshots of other accounts (other profiles) are never served.
+func (h *handler) checkAccount(w http.ResponseWriter, r *http.Request) bool {
+	v := r.URL.Query().Get("account")
+	if v == "" {
+		return true
+	}
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid account %q: expected an account id", v))
+		return false
+	}
+	if id != h.accountID {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("only snapshots of account %d are served", h.accountID))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,159 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/snapshots"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+)
+
+func get(t *testing.T, h http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerRequiresToken(t *testing.T) {
+	h := NewHandler(newTestCache(&fakeFetch{}, newFakeClock()), WithToken("s3cret"))
+
+	tests := []struct {
+		name   string
+		path   string
+		auth   string
+		status int
+	}{
+		{name: "no token", path: "/api/v1/portfolio", status: http.StatusUnauthorized},
+		{name: "wrong token", path: "/api/v1/portfolio", auth: "Bearer nope", status: http.StatusUnauthorized},
+		{name: "wrong scheme", path: "/api/v1/portfolio", auth: "Basic s3cret", status: http.StatusUnauthorized},
+		{name: "valid token", path: "/api/v1/portfolio", auth: "Bearer s3cret", status: http.StatusOK},
+		{name: "scheme is case-insensitive", path: "/api/v1/allocation", auth: "bearer s3cret", status: http.StatusOK},
+		{name: "healthz is open", path: "/healthz", status: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.auth != "" {
+				header.Set("Authorization", tt.auth)
+			}
+			rec := get(t, h, tt.path, header)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 without a WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestHandlerReportHeaders(t *testing.T) {
+	f := &fakeFetch{results: []error{nil, errors.New("boom")}}
+	clock := newFakeClock()
+	h := NewHandler(newTestCache(f, clock))
+
+	rec := get(t, h, "/api/v1/portfolio", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	fetchedAt := clock.t.Format(time.RFC3339)
+	if got := rec.Header().Get(HeaderFetchedAt); got != fetchedAt {
+		t.Errorf("%s = %q, want %q", HeaderFetchedAt, got, fetchedAt)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "private, max-age=60" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+	if rec.Header().Get(HeaderStale) != "" {
+		t.Errorf("fresh report marked stale")
+	}
+
+	clock.advance(time.Minute)
+	rec = get(t, h, "/api/v1/portfolio", nil)
+	if rec.Code != http.StatusOK || rec.Header().Get(HeaderStale) != "true" || rec.Header().Get(HeaderFetchedAt) != fetchedAt {
+		t.Errorf("after a failed refresh: status %d, headers %v; want the old report marked stale", rec.Code, rec.Header())
+	}
+}
+
+func TestHandlerReportErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		status         int
+		wantRetryAfter string
+	}{
+		{name: "rate limited", err: &trading212.HTTPError{StatusCode: 429, RetryAfterSeconds: 90}, status: http.StatusServiceUnavailable, wantRetryAfter: "90"},
+		{name: "upstream error", err: &trading212.HTTPError{StatusCode: 500}, status: http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(newTestCache(&fakeFetch{results: []error{tt.err}}, newFakeClock()))
+			rec := get(t, h, "/api/v1/holdings", nil)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"] == "" {
+				t.Errorf("body = %s, want a JSON error", rec.Body)
+			}
+		})
+	}
+}
+
+func TestHandlerSnapshotsScopedToAccount(t *testing.T) {
+	store := snapshots.NewStore(filepath.Join(t.TempDir(), "snapshots.jsonl"))
+	savedAt := time.Date(2025, 3, 1, 18, 0, 0, 0, time.UTC)
+	own, err := store.Save(&portfolio.Output{Summary: portfolio.Summary{AccountID: 42}}, savedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := store.Save(&portfolio.Output{Summary: portfolio.Summary{AccountID: 7}}, savedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler(newTestCache(&fakeFetch{}, newFakeClock()), WithSnapshots(store, 42))
+
+	rec := get(t, h, "/api/v1/snapshots", nil)
+	var list struct {
+		Snapshots []struct {
+			ID string `json:"id"`
+		} `json:"snapshots"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("status %d, body %s: %v", rec.Code, rec.Body, err)
+	}
+	if len(list.Snapshots) != 1 || list.Snapshots[0].ID != own.ID {
+		t.Errorf("snapshots = %+v, want only %s", list.Snapshots, own.ID)
+	}
+
+	for _, tt := range []struct {
+		path   string
+		status int
+	}{
+		{"/api/v1/snapshots?account=42", http.StatusOK},
+		{"/api/v1/snapshots?account=7", http.StatusForbidden},
+		{"/api/v1/snapshots?account=0", http.StatusForbidden},
+		{"/api/v1/snapshots?account=x", http.StatusBadRequest},
+		{"/api/v1/snapshots/" + own.ID, http.StatusOK},
+		{"/api/v1/snapshots/latest", http.StatusOK},
+		{"/api/v1/snapshots/" + other.ID, http.StatusNotFound},
+		{"/api/v1/snapshots/" + other.ID + "?account=7", http.StatusForbidden},
+	} {
+		if rec := get(t, h, tt.path, nil); rec.Code != tt.status {
+			t.Errorf("GET %s = %d, want %d: %s", tt.path, rec.Code, tt.status, rec.Body)
+		}
+	}
+}
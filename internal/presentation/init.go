@@ -12,11 +12,14 @@ import (
 	"github.com/nezdemkovski/folio212/internal/infrastructure/config"
 	"github.com/nezdemkovski/folio212/internal/infrastructure/secrets"
 	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+	"github.com/nezdemkovski/folio212/internal/shared/constants"
 	"github.com/nezdemkovski/folio212/internal/shared/ui"
 	"github.com/nezdemkovski/folio212/internal/shared/validation"
 )
 
 type InitModel struct {
+	profile           string // resolved profile name
+	secretKey         string // keyring key for the profile's API secret
 	form              *huh.Form
 	t212Env           string
 	t212KeyID         string
@@ -30,31 +33,41 @@ type InitModel struct {
 	height         int
 	err            error
 	cfg            *config.Config
+	savedProfile   *config.Profile
 	accountSummary *trading212.AccountSummary
 	layout         ui.Layout
 	secretSource   secrets.Source
 	secretInsecure bool
 }
 
-func NewInitModel() *InitModel {
+// NewInitModel creates the setup form for the named profile ("" selects the default profile).
+func NewInitModel(profile string) *InitModel {
 	m := &InitModel{
+		profile:     strings.ToLower(strings.TrimSpace(profile)),
 		t212Env:     "demo",
 		validateNow: true,
 		layout:      ui.NewLayout(80, 24),
 	}
 
-	// Prefill values if config already exists.
+	// Prefill values if the profile already exists.
 	if cfg, err := config.Load(); err == nil && cfg != nil {
-		if strings.TrimSpace(cfg.Trading212Env) != "" {
-			m.t212Env = strings.TrimSpace(cfg.Trading212Env)
-		}
-		if strings.TrimSpace(cfg.Trading212APIKey) != "" {
-			m.t212KeyID = strings.TrimSpace(cfg.Trading212APIKey)
+		m.profile = cfg.ProfileName(m.profile)
+		if p, err := cfg.Profile(m.profile); err == nil {
+			if strings.TrimSpace(p.Trading212Env) != "" {
+				m.t212Env = strings.TrimSpace(p.Trading212Env)
+			}
+			if strings.TrimSpace(p.Trading212APIKey) != "" {
+				m.t212KeyID = strings.TrimSpace(p.Trading212APIKey)
+			}
 		}
 	}
+	if m.profile == "" {
+		m.profile = constants.DefaultProfileName
+	}
+	m.secretKey = secrets.ProfileKey(secrets.KeyTrading212APISecret, m.profile)
 
 	// Check if a secret is already stored so we can allow "leave blank to keep existing".
-	if secret, _, _ := secrets.Get(secrets.KeyTrading212APISecret); strings.TrimSpace(secret) != "" {
+	if secret, _, _ := secrets.Get(m.secretKey); strings.TrimSpace(secret) != "" {
 		m.hasSavedSecret = true
 	}
 
@@ -142,14 +155,21 @@ func (m *InitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 
-		c := config.Default()
-		c.Trading212Env = strings.TrimSpace(strings.ToLower(m.t212Env))
-		c.Trading212APIKey = strings.TrimSpace(m.t212KeyID)
-		if c.Trading212Env != "demo" && c.Trading212Env != "live" {
-			m.err = fmt.Errorf("invalid trading212 environment %q (expected demo or live)", c.Trading212Env)
+		// Keep other profiles when adding or updating one.
+		c, err := config.Load()
+		if err != nil || c == nil {
+			c = config.Default()
+		}
+		p := config.Profile{
+			Name:             m.profile,
+			Trading212Env:    strings.TrimSpace(strings.ToLower(m.t212Env)),
+			Trading212APIKey: strings.TrimSpace(m.t212KeyID),
+		}
+		if p.Trading212Env != "demo" && p.Trading212Env != "live" {
+			m.err = fmt.Errorf("invalid trading212 environment %q (expected demo or live)", p.Trading212Env)
 			return m, tea.Quit
 		}
-		if err := validation.ValidateNonEmpty("trading212 api key", c.Trading212APIKey); err != nil {
+		if err := validation.ValidateNonEmpty("trading212 api key", p.Trading212APIKey); err != nil {
 			m.err = err
 			return m, tea.Quit
 		}
@@ -157,7 +177,7 @@ func (m *InitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		secret := strings.TrimSpace(m.t212Secret)
 		if secret == "" && m.hasSavedSecret {
 			// Reuse previously stored secret (we don't prefill the field).
-			prev, _, _ := secrets.Get(secrets.KeyTrading212APISecret)
+			prev, _, _ := secrets.Get(m.secretKey)
 			secret = strings.TrimSpace(prev)
 		}
 		if err := validation.ValidateNonEmpty("trading212 api secret", secret); err != nil {
@@ -167,11 +187,11 @@ func (m *InitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if m.validateNow {
 			baseURL := trading212.BaseURLDemo
-			if c.Trading212Env == "live" {
+			if p.Trading212Env == "live" {
 				baseURL = trading212.BaseURLLive
 			}
 
-			client, err := trading212.NewClient(baseURL, c.Trading212APIKey, secret)
+			client, err := trading212.NewClient(baseURL, p.Trading212APIKey, secret)
 			if err != nil {
 				m.err = err
 				return m, tea.Quit
@@ -186,9 +206,11 @@ func (m *InitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.validationWarning = fmt.Errorf("validation failed: %w", humanizeTrading212AuthError(err))
 			} else {
 				m.accountSummary = summary
+				p.Trading212AccountID = summary.ID
 			}
 		}
 
+		c.SetProfile(m.profile, p)
 		if err := config.Save(c); err != nil {
 			m.err = err
 			return m, tea.Quit
@@ -196,7 +218,7 @@ func (m *InitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Only store when user provided a new secret, or when we didn't have one saved already.
 		if strings.TrimSpace(m.t212Secret) != "" || !m.hasSavedSecret {
-			source, insecure, err := secrets.Set(secrets.KeyTrading212APISecret, secret)
+			source, insecure, err := secrets.Set(m.secretKey, secret)
 			if err != nil {
 				m.err = fmt.Errorf("failed to save Trading212 API secret: %w", err)
 				return m, tea.Quit
@@ -207,6 +229,7 @@ func (m *InitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.cfg = c
+		m.savedProfile = &p
 		return m, tea.Quit
 	}
 
@@ -228,9 +251,14 @@ func (m *InitModel) View() tea.View {
 		"  Help: https://helpcentre.trading212.com/hc/en-us/articles/14584770928157-Trading-212-API-key",
 	}, "\n")
 
+	subtitle := "Check your Trading212 holdings from the terminal. AI ready."
+	if m.profile != constants.DefaultProfileName {
+		subtitle += "\nProfile: " + m.profile
+	}
+
 	sections := []string{
 		m.layout.RenderLogo(),
-		m.layout.RenderSubtitle("Check your Trading212 holdings from the terminal. AI ready."),
+		m.layout.RenderSubtitle(subtitle),
 		m.layout.RenderBody(ui.Meta.Render(help) + "\n\n" + m.form.View()),
 	}
 
@@ -247,6 +275,11 @@ func (m *InitModel) Config() *config.Config {
 	return m.cfg
 }
 
+// Profile returns the profile saved by the form, or nil when init did not complete.
+func (m *InitModel) Profile() *config.Profile {
+	return m.savedProfile
+}
+
 func (m *InitModel) AccountSummary() *trading212.AccountSummary {
 	return m.accountSummary
 }
@@ -268,21 +301,23 @@ func (m *InitModel) SecretSource() secrets.Source {
 	return m.secretSource
 }
 
-func RenderInitCompletion(cfg *config.Config, summary *trading212.AccountSummary, validationWarning error, secretSource secrets.Source) string {
+func RenderInitCompletion(profile *config.Profile, summary *trading212.AccountSummary, validationWarning error, secretSource secrets.Source) string {
 	var s strings.Builder
 
 	s.WriteString(ui.SuccessStyle.Render(ui.SymbolDone) + " " + ui.Title.Render("Initialization Complete"))
 	s.WriteString("\n\n")
 
-	if cfg != nil {
+	if profile != nil {
 		s.WriteString(ui.SectionHeader("Config"))
 		s.WriteString("\n")
-		if cfg.Trading212Env != "" {
-			s.WriteString(ui.Bullet(fmt.Sprintf("trading212 env: %s", cfg.Trading212Env)))
+		s.WriteString(ui.Bullet(fmt.Sprintf("profile: %s", profile.Name)))
+		s.WriteString("\n")
+		if profile.Trading212Env != "" {
+			s.WriteString(ui.Bullet(fmt.Sprintf("trading212 env: %s", profile.Trading212Env)))
 			s.WriteString("\n")
 		}
-		if cfg.Trading212APIKey != "" {
-			s.WriteString(ui.Bullet(fmt.Sprintf("trading212 api key: %s", maskString(cfg.Trading212APIKey, 4))))
+		if profile.Trading212APIKey != "" {
+			s.WriteString(ui.Bullet(fmt.Sprintf("trading212 api key: %s", maskString(profile.Trading212APIKey, 4))))
 			s.WriteString("\n")
 		}
 	}
@@ -330,7 +365,11 @@ func RenderInitCompletion(cfg *config.Config, summary *trading212.AccountSummary
 
 	s.WriteString("\n")
 	s.WriteString(ui.Meta.Render("Next steps:") + "\n")
-	s.WriteString(ui.Bullet("folio212 portfolio  - Show current holdings") + "\n")
+	if profile != nil && profile.Name != constants.DefaultProfileName {
+		s.WriteString(ui.Bullet(fmt.Sprintf("folio212 portfolio --profile %s  - Show current holdings", profile.Name)) + "\n")
+	} else {
+		s.WriteString(ui.Bullet("folio212 portfolio  - Show current holdings") + "\n")
+	}
 
 	return ui.Container.Render(s.String())
 }
@@ -0,0 +1,109 @@
+package presentation
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+// MetricsOptions describes the state of the report passed to RenderPortfolioMetrics.
+type MetricsOptions struct {
+	FetchedAt time.Time
+	Stale     bool // the last refresh failed; values are from FetchedAt
+}
+
+type metricSample struct {
+	labels []string // name, value pairs
+	value  float64
+}
+
+type metricFamily struct {
+	name    string
+	help    string
+	samples []metricSample
+}
+
+// RenderPortfolioMetrics writes the report as Prometheus gauges (text exposition format 0.0.4).
+// Money is in the account currency, which every sample carries as the currency label. A nil
+// output writes folio212_up 0 only.
+func RenderPortfolioMetrics(output *portfolio.Output, opts MetricsOptions, w io.Writer) error {
+	up := metricFamily{name: "folio212_up", help: "Whether a portfolio report is available (1) or not (0)."}
+	if output == nil {
+		up.samples = []metricSample{{value: 0}}
+		return writeMetricFamilies(w, []metricFamily{up})
+	}
+	up.samples = []metricSample{{value: 1}}
+
+	stale := 0.0
+	if opts.Stale {
+		stale = 1
+	}
+	d := output.Summary.Derived
+	account := []string{"currency", output.Summary.Currency}
+	if output.Summary.AccountID != 0 {
+		account = append(account, "account_id", strconv.FormatInt(output.Summary.AccountID, 10))
+	}
+	gauge := func(name, help string, v float64) metricFamily {
+		return metricFamily{name: name, help: help, samples: []metricSample{{labels: account, value: v}}}
+	}
+
+	families := []metricFamily{
+		up,
+		{name: "folio212_report_stale", help: "1 when the last refresh failed and older values are served.", samples: []metricSample{{value: stale}}},
+		{name: "folio212_report_fetched_timestamp_seconds", help: "Unix time the report was fetched from Trading212.", samples: []metricSample{{value: float64(opts.FetchedAt.Unix())}}},
+		gauge("folio212_account_total", "Account total (free cash + holdings + pie cash).", d.AccountTotal),
+		gauge("folio212_free_cash", "Cash available to trade plus cash reserved for orders.", d.FreeCash),
+		gauge("folio212_pie_cash", "Uninvested cash inside pies.", d.PieCash),
+		gauge("folio212_holdings_value", "Market value of executed holdings.", d.HoldingsValue),
+		gauge("folio212_holdings_cost", "Cost basis of executed holdings.", d.HoldingsCost),
+		gauge("folio212_holdings_unrealized_pnl", "Unrealized profit and loss of executed holdings.", d.HoldingsPnL),
+	}
+
+	value := metricFamily{name: "folio212_holding_market_value", help: "Market value of a holding in the account currency."}
+	qty := metricFamily{name: "folio212_holding_quantity", help: "Shares held."}
+	weight := metricFamily{name: "folio212_holding_weight_percent", help: "Share of the holdings value, in percent."}
+	for _, h := range output.Holdings {
+		labels := []string{"ticker", h.Ticker, "name", h.Name, "isin", h.ISIN, "currency", output.Summary.Currency}
+		value.samples = append(value.samples, metricSample{labels: labels, value: h.MarketValue})
+		qty.samples = append(qty.samples, metricSample{labels: labels[:6], value: h.Qty})
+		weight.samples = append(weight.samples, metricSample{labels: labels[:6], value: h.HoldingsPct})
+	}
+	families = append(families, value, qty, weight)
+	return writeMetricFamilies(w, families)
+}
+
+func writeMetricFamilies(w io.Writer, families []metricFamily) error {
+	var s strings.Builder
+	for _, f := range families {
+		if len(f.samples) == 0 {
+			continue
+		}
+		s.WriteString("# HELP " + f.name + " " + f.help + "\n")
+		s.WriteString("# TYPE " + f.name + " gauge\n")
+		for _, sample := range f.samples {
+			s.WriteString(f.name)
+			if len(sample.labels) > 0 {
+				s.WriteByte('{')
+				for i := 0; i+1 < len(sample.labels); i += 2 {
+					if i > 0 {
+						s.WriteByte(',')
+					}
+					s.WriteString(sample.labels[i] + `="` + escapeLabelValue(sample.labels[i+1]) + `"`)
+				}
+				s.WriteByte('}')
+			}
+			s.WriteString(" " + strconv.FormatFloat(sample.value, 'g', -1, 64) + "\n")
+		}
+	}
+	_, err := io.WriteString(w, s.String())
+	return err
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelEscaper.Replace(v)
+}
@@ -0,0 +1,88 @@
+package presentation
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+func TestRenderPortfolioMetrics(t *testing.T) {
+	output := &portfolio.Output{
+		Summary: portfolio.Summary{
+			AccountID: 42,
+			Currency:  "EUR",
+			Derived: portfolio.DerivedMetrics{
+				AccountTotal: 1600, FreeCash: 100, PieCash: 0, HoldingsValue: 1500, HoldingsCost: 1200, HoldingsPnL: 300,
+			},
+		},
+		Holdings: []portfolio.HoldingRow{
+			{Ticker: "AAPL_US_EQ", Name: "Apple", ISIN: "US0378331005", Qty: 5, MarketValue: 1000, HoldingsPct: 66.6667},
+			{Ticker: "ODD_EQ", Name: "Say \"hi\"\\back\nslash", ISIN: "XX0000000000", Qty: 0.5, MarketValue: 500, HoldingsPct: 33.3333},
+		},
+	}
+	opts := MetricsOptions{FetchedAt: time.Unix(1740830400, 0), Stale: true}
+
+	const want = `# HELP folio212_up Whether a portfolio report is available (1) or not (0).
+# TYPE folio212_up gauge
+folio212_up 1
+# HELP folio212_report_stale 1 when the last refresh failed and older values are served.
+# TYPE folio212_report_stale gauge
+folio212_report_stale 1
+# HELP folio212_report_fetched_timestamp_seconds Unix time the report was fetched from Trading212.
+# TYPE folio212_report_fetched_timestamp_seconds gauge
+folio212_report_fetched_timestamp_seconds 1.7408304e+09
+# HELP folio212_account_total Account total (free cash + holdings + pie cash).
+# TYPE folio212_account_total gauge
+folio212_account_total{currency="EUR",account_id="42"} 1600
+# HELP folio212_free_cash Cash available to trade plus cash reserved for orders.
+# TYPE folio212_free_cash gauge
+folio212_free_cash{currency="EUR",account_id="42"} 100
+# HELP folio212_pie_cash Uninvested cash inside pies.
+# TYPE folio212_pie_cash gauge
+folio212_pie_cash{currency="EUR",account_id="42"} 0
+# HELP folio212_holdings_value Market value of executed holdings.
+# TYPE folio212_holdings_value gauge
+folio212_holdings_value{currency="EUR",account_id="42"} 1500
+# HELP folio212_holdings_cost Cost basis of executed holdings.
+# TYPE folio212_holdings_cost gauge
+folio212_holdings_cost{currency="EUR",account_id="42"} 1200
+# HELP folio212_holdings_unrealized_pnl Unrealized profit and loss of executed holdings.
+# TYPE folio212_holdings_unrealized_pnl gauge
+folio212_holdings_unrealized_pnl{currency="EUR",account_id="42"} 300
+# HELP folio212_holding_market_value Market value of a holding in the account currency.
+# TYPE folio212_holding_market_value gauge
+folio212_holding_market_value{ticker="AAPL_US_EQ",name="Apple",isin="US0378331005",currency="EUR"} 1000
+folio212_holding_market_value{ticker="ODD_EQ",name="Say \"hi\"\\back\nslash",isin="XX0000000000",currency="EUR"} 500
+# HELP folio212_holding_quantity Shares held.
+# TYPE folio212_holding_quantity gauge
+folio212_holding_quantity{ticker="AAPL_US_EQ",name="Apple",isin="US0378331005"} 5
+folio212_holding_quantity{ticker="ODD_EQ",name="Say \"hi\"\\back\nslash",isin="XX0000000000"} 0.5
+# HELP folio212_holding_weight_percent Share of the holdings value, in percent.
+# TYPE folio212_holding_weight_percent gauge
+folio212_holding_weight_percent{ticker="AAPL_US_EQ",name="Apple",isin="US0378331005"} 66.6667
+folio212_holding_weight_percent{ticker="ODD_EQ",name="Say \"hi\"\\back\nslash",isin="XX0000000000"} 33.3333
+`
+	var buf bytes.Buffer
+	if err := RenderPortfolioMetrics(output, opts, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("metrics changed:\n--- got\n%s--- want\n%s", got, want)
+	}
+}
+
+func TestRenderPortfolioMetricsWithoutReport(t *testing.T) {
+	const want = `# HELP folio212_up Whether a portfolio report is available (1) or not (0).
+# TYPE folio212_up gauge
+folio212_up 0
+`
+	var buf bytes.Buffer
+	if err := RenderPortfolioMetrics(nil, MetricsOptions{}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+func RenderOrdersText(output *portfolio.OrdersOutput, w io.Writer) error {
+	var s strings.Builder
+
+	s.WriteString(fmt.Sprintf("Report date: %s\n", output.Report.ReportDate))
+	s.WriteString(fmt.Sprintf("Reporting period: %s\n", formatPeriodLabel(output.Report.Period)))
+	if output.Ticker != "" {
+		s.WriteString(fmt.Sprintf("Ticker: %s\n", output.Ticker))
+	}
+	s.WriteString("\n")
+
+	s.WriteString(fmt.Sprintf("Executed orders (%s)\n", currencyLabel(output.Currency)))
+	s.WriteString(fmt.Sprintf("  count: %d\n", output.Totals.Count))
+	s.WriteString(fmt.Sprintf("  buys: %.2f\n", output.Totals.Buys))
+	s.WriteString(fmt.Sprintf("  sells: %.2f\n", output.Totals.Sells))
+	s.WriteString(fmt.Sprintf("  fees: %.2f\n", output.Totals.Fees))
+	s.WriteString(fmt.Sprintf("  net (buys - sells): %.2f\n\n", output.Totals.Net))
+
+	if len(output.Orders) == 0 {
+		s.WriteString("No executed orders in this period.\n")
+	} else {
+		for _, o := range output.Orders {
+			s.WriteString(renderOrder(o))
+		}
+	}
+
+	_, err := w.Write([]byte(s.String()))
+	return err
+}
+
+func renderOrder(o portfolio.OrderRow) string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("%s  %-4s %-10s %.6g @ %.6g %s  = %.2f %s",
+		dateOnly(o.FilledAt), o.Side, o.Ticker, o.Qty, o.Price, o.InstrumentCurrency, o.Value, o.AccountCurrency))
+	if o.Fees > 0 {
+		s.WriteString(fmt.Sprintf(" (fees %.2f)", o.Fees))
+	}
+	if o.RealizedPnL != nil && o.Side == portfolio.SideSell {
+		s.WriteString(fmt.Sprintf(" | realized PnL: %.2f", *o.RealizedPnL))
+	}
+	s.WriteString("\n")
+	return s.String()
+}
+
+func currencyLabel(currency string) string {
+	if currency == "" {
+		return "account currency"
+	}
+	return currency
+}
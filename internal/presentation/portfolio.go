@@ -4,11 +4,18 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
 )
 
 func RenderPortfolioText(output *portfolio.Output, w io.Writer) error {
+	return RenderPortfolioTextFields(output, nil, w)
+}
+
+// RenderPortfolioTextFields is RenderPortfolioText with holdings shown as a table of the given
+// fields (see ParseHoldingFields); without fields each holding is printed in full.
+func RenderPortfolioTextFields(output *portfolio.Output, fields []string, w io.Writer) error {
 	var s strings.Builder
 
 	s.WriteString(fmt.Sprintf("Report date: %s\n", output.Report.ReportDate))
@@ -39,7 +46,17 @@ func RenderPortfolioText(output *portfolio.Output, w io.Writer) error {
 		s.WriteString("  fx impact: n/a\n")
 	}
 	s.WriteString(fmt.Sprintf("  return: %.2f%%\n", output.Summary.Derived.HoldingsReturnPct))
-	s.WriteString(fmt.Sprintf("  twr (est.): %.2f%%\n\n", output.Summary.Derived.TWRPctEst))
+	if portfolio.FlowAdjustedTWR(output.Summary.Derived.TWRMethod) {
+		s.WriteString(fmt.Sprintf("  twr (flow-adjusted, account): %.2f%%\n", output.Summary.Derived.TWRPctEst))
+	} else {
+		s.WriteString(fmt.Sprintf("  twr (est.): %.2f%%\n", output.Summary.Derived.TWRPctEst))
+	}
+	if output.Summary.Derived.MWRPct != nil {
+		s.WriteString(fmt.Sprintf("  mwr (annualized, account): %.2f%%\n", *output.Summary.Derived.MWRPct))
+	} else if output.Summary.Derived.MWRDescription != "" {
+		s.WriteString("  mwr (annualized, account): n/a\n")
+	}
+	s.WriteString("\n")
 
 	s.WriteString(fmt.Sprintf("Account total (as of %s, %s)\n", output.Report.ReportDate, output.Summary.Currency))
 	s.WriteString(fmt.Sprintf("  free cash: %.2f\n", output.Summary.Derived.FreeCash))
@@ -52,6 +69,22 @@ func RenderPortfolioText(output *portfolio.Output, w io.Writer) error {
 	}
 	s.WriteString("\n")
 
+	if len(output.Accounts) > 0 {
+		s.WriteString(fmt.Sprintf("Accounts (converted to %s)\n", output.Summary.Currency))
+		for _, a := range output.Accounts {
+			s.WriteString(fmt.Sprintf("  %s (account %d): total %.2f %s", a.Profile, a.AccountID, a.Derived.AccountTotal, a.Currency))
+			if a.FXSource != portfolio.FXSourceSame {
+				s.WriteString(fmt.Sprintf(" = %.2f %s @ %.6g (%s)", a.AccountTotal, output.Summary.Currency, a.FXRate, a.FXSource))
+			}
+			s.WriteString(fmt.Sprintf(" | return: %.2f%% | twr: %.2f%%", a.Derived.HoldingsReturnPct, a.Derived.TWRPctEst))
+			if a.Derived.MWRPct != nil {
+				s.WriteString(fmt.Sprintf(" | mwr: %.2f%%", *a.Derived.MWRPct))
+			}
+			s.WriteString("\n")
+		}
+		s.WriteString("\n")
+	}
+
 	s.WriteString(fmt.Sprintf("Allocation (holdings only, as of %s):\n", output.Report.ReportDate))
 	if output.Summary.Derived.HoldingsValue <= 0 {
 		s.WriteString("  n/a (no holdings)\n")
@@ -63,16 +96,27 @@ func RenderPortfolioText(output *portfolio.Output, w io.Writer) error {
 	}
 	s.WriteString("\n")
 
-	if !isAllTime(output.Report.Period) {
+	if flows := output.PeriodFlows; flows != nil {
 		s.WriteString(fmt.Sprintf("Period flows (executed trades, %s)\n", output.Summary.Currency))
-		s.WriteString("  buys: 0.00\n")
-		s.WriteString("  sells: 0.00\n")
-		s.WriteString("  net: 0.00\n")
-		s.WriteString("  Note: This is not implemented yet (requires History - Orders permission).\n\n")
+		if flows.Available {
+			s.WriteString(fmt.Sprintf("  orders: %d\n", flows.OrderCount))
+			s.WriteString(fmt.Sprintf("  buys: %.2f\n", flows.Buys))
+			s.WriteString(fmt.Sprintf("  sells: %.2f\n", flows.Sells))
+			s.WriteString(fmt.Sprintf("  fees: %.2f\n", flows.Fees))
+			s.WriteString(fmt.Sprintf("  net (buys - sells): %.2f\n", flows.Net))
+		} else {
+			s.WriteString("  n/a\n")
+		}
+		for _, warning := range flows.Warnings {
+			s.WriteString(fmt.Sprintf("  WARNING: %s\n", warning))
+		}
+		s.WriteString("\n")
 	}
 
 	if len(output.Holdings) == 0 {
 		s.WriteString("No open positions.\n")
+	} else if len(fields) > 0 {
+		renderHoldingsTable(&s, output.Holdings, fields)
 	} else {
 		for _, h := range output.Holdings {
 			s.WriteString(renderHolding(h, output.Summary.Currency))
@@ -83,14 +127,50 @@ func RenderPortfolioText(output *portfolio.Output, w io.Writer) error {
 	return err
 }
 
+// renderHoldingsTable writes the selected fields as right-aligned columns.
+func renderHoldingsTable(s *strings.Builder, rows []portfolio.HoldingRow, fields []string) {
+	cols := selectHoldingColumns(fields)
+	tw := tabwriter.NewWriter(s, 0, 0, 2, ' ', tabwriter.AlignRight)
+	for _, c := range cols {
+		fmt.Fprintf(tw, "%s\t", c.name)
+	}
+	fmt.Fprintln(tw)
+	n := csvNumbers{decimal: "."}
+	for _, h := range rows {
+		for _, c := range cols {
+			v := c.value(h, n)
+			if v == "" {
+				v = "-"
+			}
+			fmt.Fprintf(tw, "%s\t", v)
+		}
+		fmt.Fprintln(tw)
+	}
+	tw.Flush()
+}
+
 func renderHolding(h portfolio.HoldingRow, currency string) string {
 	fxImpactStr := "n/a"
 	if h.FXImpact != nil {
 		fxImpactStr = fmt.Sprintf("%.2f", *h.FXImpact)
 	}
 
+	mwrStr := ""
+	if h.MWRPct != nil {
+		mwrStr = fmt.Sprintf("  mwr (annualized): %.2f%%\n", *h.MWRPct)
+	}
+
+	accounts := ""
+	if len(h.Accounts) > 0 {
+		parts := make([]string, 0, len(h.Accounts))
+		for _, a := range h.Accounts {
+			parts = append(parts, fmt.Sprintf("%s %.6g (%.2f %s)", a.Profile, a.Qty, a.MarketValue, currency))
+		}
+		accounts = "  accounts: " + strings.Join(parts, ", ") + "\n"
+	}
+
 	return fmt.Sprintf(
-		"%s (%s)\n  market value: %.2f %s (%.2f%% of holdings)\n  isin: %s | opened: %s\n  shares: %.6g | tradable: %.6g | in pies: %.6g\n  avg price: %.6g %s | current price: %.6g %s\n  invested: %.2f %s | uPnL: %.2f %s\n  fx impact (%s): %s %s\n\n",
+		"%s (%s)\n  market value: %.2f %s (%.2f%% of holdings)\n  isin: %s | opened: %s\n  shares: %.6g | tradable: %.6g | in pies: %.6g\n  avg price: %.6g %s | current price: %.6g %s\n  invested: %.2f %s | uPnL: %.2f %s\n  fx impact (%s): %s %s\n%s%s\n",
 		h.Name,
 		h.Ticker,
 		h.MarketValue,
@@ -112,6 +192,8 @@ func renderHolding(h portfolio.HoldingRow, currency string) string {
 		portfolio.ChooseFXPair(h.FXPair, h.InstrumentCurrency, currency),
 		fxImpactStr,
 		currency,
+		mwrStr,
+		accounts,
 	)
 }
 
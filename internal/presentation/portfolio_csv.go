@@ -0,0 +1,226 @@
+package presentation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+const (
+	CSVSectionHoldings   = "holdings"
+	CSVSectionAllocation = "allocation"
+	CSVSectionSummary    = "summary"
+)
+
+var CSVSections = []string{CSVSectionHoldings, CSVSectionAllocation, CSVSectionSummary}
+
+// CSVOptions controls portfolio CSV output. The zero value writes holdings with ',' and '.'.
+type CSVOptions struct {
+	Section          string
+	Delimiter        rune
+	DecimalSeparator string   // "." or ","
+	Fields           []string // holdings columns to write (see ParseHoldingFields); empty = all
+	// HoldingsCount is the account's number of holdings for the summary row, counted before
+	// selection (--filter, --top); 0 = len(output.Holdings).
+	HoldingsCount int
+}
+
+func (o CSVOptions) withDefaults() CSVOptions {
+	if o.Section == "" {
+		o.Section = CSVSectionHoldings
+	}
+	if o.Delimiter == 0 {
+		o.Delimiter = ','
+	}
+	if o.DecimalSeparator == "" {
+		o.DecimalSeparator = "."
+	}
+	return o
+}
+
+// Validate checks the section and that numbers cannot be confused with field boundaries.
+func (o CSVOptions) Validate() error {
+	o = o.withDefaults()
+	if !slices.Contains(CSVSections, o.Section) {
+		return fmt.Errorf("unknown section %q (expected %s)", o.Section, strings.Join(CSVSections, ", "))
+	}
+	if len(o.Fields) > 0 && o.Section != CSVSectionHoldings {
+		return fmt.Errorf("field selection only applies to the holdings section")
+	}
+	if o.DecimalSeparator != "." && o.DecimalSeparator != "," {
+		return fmt.Errorf("invalid decimal separator %q (expected . or ,)", o.DecimalSeparator)
+	}
+	if string(o.Delimiter) == o.DecimalSeparator {
+		return fmt.Errorf("delimiter and decimal separator must differ (e.g. --delimiter ';' with --decimal-separator ',')")
+	}
+	return nil
+}
+
+// ParseCSVDelimiter accepts a single character or the names tab, comma, semicolon and pipe.
+func ParseCSVDelimiter(s string) (rune, error) {
+	switch strings.ToLower(s) {
+	case "", "comma":
+		return ',', nil
+	case "tab", `\t`:
+		return '\t', nil
+	case "semicolon":
+		return ';', nil
+	case "pipe":
+		return '|', nil
+	}
+	r := []rune(s)
+	if len(r) != 1 || r[0] == '"' || r[0] == '\r' || r[0] == '\n' {
+		return 0, fmt.Errorf("invalid delimiter %q (expected one character, or tab, comma, semicolon, pipe)", s)
+	}
+	return r[0], nil
+}
+
+// csvNumbers formats numbers with the configured decimal separator. Money uses two decimals;
+// quantities and prices keep full precision.
+type csvNumbers struct {
+	decimal string
+}
+
+func (n csvNumbers) money(v float64) string {
+	return n.localize(strconv.FormatFloat(v, 'f', 2, 64))
+}
+
+func (n csvNumbers) num(v float64) string {
+	return n.localize(strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+func (n csvNumbers) optMoney(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return n.money(*v)
+}
+
+func (n csvNumbers) localize(s string) string {
+	if n.decimal == "" || n.decimal == "." {
+		return s
+	}
+	return strings.Replace(s, ".", n.decimal, 1)
+}
+
+type csvColumn[T any] struct {
+	name  string
+	value func(row T, n csvNumbers) string
+}
+
+// Header order is part of the format: columns are only ever appended.
+var holdingCSVColumns = []csvColumn[portfolio.HoldingRow]{
+	{"ticker", func(h portfolio.HoldingRow, _ csvNumbers) string { return h.Ticker }},
+	{"name", func(h portfolio.HoldingRow, _ csvNumbers) string { return h.Name }},
+	{"isin", func(h portfolio.HoldingRow, _ csvNumbers) string { return h.ISIN }},
+	{"opened_at", func(h portfolio.HoldingRow, _ csvNumbers) string { return h.OpenedAt }},
+	{"qty", func(h portfolio.HoldingRow, n csvNumbers) string { return n.num(h.Qty) }},
+	{"tradable_qty", func(h portfolio.HoldingRow, n csvNumbers) string { return n.num(h.TradableQty) }},
+	{"qty_in_pies", func(h portfolio.HoldingRow, n csvNumbers) string { return n.num(h.QtyInPies) }},
+	{"instrument_currency", func(h portfolio.HoldingRow, _ csvNumbers) string { return h.InstrumentCurrency }},
+	{"avg_price_paid", func(h portfolio.HoldingRow, n csvNumbers) string { return n.num(h.AvgPricePaid) }},
+	{"current_price", func(h portfolio.HoldingRow, n csvNumbers) string { return n.num(h.CurrentPrice) }},
+	{"account_currency", func(h portfolio.HoldingRow, _ csvNumbers) string { return h.AccountCurrency }},
+	{"invested", func(h portfolio.HoldingRow, n csvNumbers) string { return n.money(h.Invested) }},
+	{"market_value", func(h portfolio.HoldingRow, n csvNumbers) string { return n.money(h.MarketValue) }},
+	{"unrealized_pnl", func(h portfolio.HoldingRow, n csvNumbers) string { return n.money(h.UnrealizedPnL) }},
+	{"fx_impact", func(h portfolio.HoldingRow, n csvNumbers) string { return n.optMoney(h.FXImpact) }},
+	{"fx_pair", func(h portfolio.HoldingRow, _ csvNumbers) string { return h.FXPair }},
+	{"holdings_pct", func(h portfolio.HoldingRow, n csvNumbers) string { return n.num(h.HoldingsPct) }},
+	{"holdings_bps", func(h portfolio.HoldingRow, _ csvNumbers) string { return strconv.Itoa(h.HoldingsBps) }},
+	{"mwr_pct", func(h portfolio.HoldingRow, n csvNumbers) string { return n.optMoney(h.MWRPct) }},
+}
+
+var allocationCSVColumns = []csvColumn[portfolio.AllocationRow]{
+	{"ticker", func(a portfolio.AllocationRow, _ csvNumbers) string { return a.Ticker }},
+	{"market_value", func(a portfolio.AllocationRow, n csvNumbers) string { return n.money(a.MarketValue) }},
+	{"holdings_pct", func(a portfolio.AllocationRow, n csvNumbers) string { return n.num(a.HoldingsPct) }},
+	{"holdings_bps", func(a portfolio.AllocationRow, _ csvNumbers) string { return strconv.Itoa(a.HoldingsBps) }},
+}
+
+// The summary section is a single row so it can be appended to a sheet on every run.
+// csvSummary is the summary row: the report plus the account's full holdings count.
+type csvSummary struct {
+	*portfolio.Output
+	holdingsCount int
+}
+
+var summaryCSVColumns = []csvColumn[csvSummary]{
+	{"report_date", func(o csvSummary, _ csvNumbers) string { return o.Report.ReportDate }},
+	{"account_id", func(o csvSummary, _ csvNumbers) string {
+		if o.Summary.AccountID == 0 {
+			return ""
+		}
+		return strconv.FormatInt(o.Summary.AccountID, 10)
+	}},
+	{"currency", func(o csvSummary, _ csvNumbers) string { return o.Summary.Currency }},
+	{"account_total", func(o csvSummary, n csvNumbers) string { return n.money(o.Summary.Derived.AccountTotal) }},
+	{"holdings_value", func(o csvSummary, n csvNumbers) string { return n.money(o.Summary.Derived.HoldingsValue) }},
+	{"pie_cash", func(o csvSummary, n csvNumbers) string { return n.money(o.Summary.Derived.PieCash) }},
+	{"allocated", func(o csvSummary, n csvNumbers) string { return n.money(o.Summary.Derived.Allocated) }},
+	{"free_cash", func(o csvSummary, n csvNumbers) string { return n.money(o.Summary.Derived.FreeCash) }},
+	{"holdings_cost", func(o csvSummary, n csvNumbers) string { return n.money(o.Summary.Derived.HoldingsCost) }},
+	{"holdings_pnl", func(o csvSummary, n csvNumbers) string { return n.money(o.Summary.Derived.HoldingsPnL) }},
+	{"holdings_fx_impact", func(o csvSummary, n csvNumbers) string { return n.optMoney(o.Summary.Derived.HoldingsFXImpact) }},
+	{"holdings_return_pct", func(o csvSummary, n csvNumbers) string { return n.num(o.Summary.Derived.HoldingsReturnPct) }},
+	{"twr_pct_est", func(o csvSummary, n csvNumbers) string { return n.num(o.Summary.Derived.TWRPctEst) }},
+	{"twr_method", func(o csvSummary, _ csvNumbers) string { return o.Summary.Derived.TWRMethod }},
+	{"mwr_pct", func(o csvSummary, n csvNumbers) string { return n.optMoney(o.Summary.Derived.MWRPct) }},
+	{"holdings_count", func(o csvSummary, _ csvNumbers) string { return strconv.Itoa(o.holdingsCount) }},
+}
+
+// RenderPortfolioCSV writes one section of the report as CSV with a stable header.
+func RenderPortfolioCSV(output *portfolio.Output, opts CSVOptions, w io.Writer) error {
+	opts = opts.withDefaults()
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	n := csvNumbers{decimal: opts.DecimalSeparator}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = opts.Delimiter
+
+	var err error
+	switch opts.Section {
+	case CSVSectionHoldings:
+		err = writeCSVRows(cw, selectHoldingColumns(opts.Fields), output.Holdings, n)
+	case CSVSectionAllocation:
+		err = writeCSVRows(cw, allocationCSVColumns, output.Allocation, n)
+	case CSVSectionSummary:
+		count := opts.HoldingsCount
+		if count == 0 {
+			count = len(output.Holdings)
+		}
+		err = writeCSVRows(cw, summaryCSVColumns, []csvSummary{{Output: output, holdingsCount: count}}, n)
+	}
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeCSVRows[T any](cw *csv.Writer, columns []csvColumn[T], rows []T, n csvNumbers) error {
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	record := make([]string, len(columns))
+	for _, row := range rows {
+		for i, c := range columns {
+			record[i] = c.value(row, n)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"testing"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+func TestRenderPortfolioCSVSummaryCountsWholeAccount(t *testing.T) {
+	output := &portfolio.Output{
+		Holdings: []portfolio.HoldingRow{
+			{Ticker: "AAPL", MarketValue: 300},
+			{Ticker: "MSFT", MarketValue: 200},
+			{Ticker: "VWCE", MarketValue: 100},
+		},
+	}
+	opts := CSVOptions{Section: CSVSectionSummary, HoldingsCount: len(output.Holdings)}
+	portfolio.HoldingSelection{Top: 1}.Apply(output)
+
+	var buf bytes.Buffer
+	if err := RenderPortfolioCSV(output, opts, &buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	col := slices.Index(records[0], "holdings_count")
+	if col < 0 {
+		t.Fatalf("no holdings_count column in %v", records[0])
+	}
+	if got := records[1][col]; got != "3" {
+		t.Errorf("holdings_count = %s after --top 1, want 3", got)
+	}
+}
@@ -0,0 +1,213 @@
+package presentation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/infrastructure/trading212"
+	"github.com/nezdemkovski/folio212/internal/shared/jsonschema"
+)
+
+// fakeAccount serves a EUR account holding a USD stock (with FX impact) and a EUR fund (without),
+// plus one page each of order, dividend and deposit history.
+func fakeAccount(t *testing.T) *trading212.Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v0/equity/account/summary", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id": 42, "currency": "EUR", "totalValue": 1600,
+			"cash": {"availableToTrade": 100, "inPies": 0, "reservedForOrders": 0},
+			"investments": {"currentValue": 1500, "totalCost": 1200, "realizedProfitLoss": 0, "unrealizedProfitLoss": 300}}`)
+	})
+	mux.HandleFunc("GET /api/v0/equity/positions", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[
+			{"instrument": {"ticker": "AAPL_US_EQ", "name": "Apple", "isin": "US0378331005", "currency": "USD"},
+			 "quantity": 5, "quantityAvailableForTrading": 5, "averagePricePaid": 150, "currentPrice": 200,
+			 "createdAt": "2025-01-10T10:00:00Z",
+			 "walletImpact": {"currency": "EUR", "totalCost": 800, "currentValue": 1000, "unrealizedProfitLoss": 200, "fxImpact": 10}},
+			{"instrument": {"ticker": "VWCEd_EQ", "name": "Vanguard FTSE All-World", "isin": "IE00BK5BQT80", "currency": "EUR"},
+			 "quantity": 4, "quantityAvailableForTrading": 4, "averagePricePaid": 100, "currentPrice": 125,
+			 "createdAt": "2025-02-10T10:00:00Z",
+			 "walletImpact": {"currency": "EUR", "totalCost": 400, "currentValue": 500, "unrealizedProfitLoss": 100}}]`)
+	})
+	mux.HandleFunc("GET /api/v0/equity/history/orders", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"items": [
+			{"order": {"id": 2, "createdAt": "2025-02-10T10:00:00Z", "status": "FILLED", "side": "BUY", "ticker": "VWCEd_EQ",
+			           "quantity": 4, "filledQuantity": 4, "currency": "EUR"},
+			 "fill": {"id": 2, "filledAt": "2025-02-10T10:00:01Z", "price": 100, "quantity": 4,
+			          "walletImpact": {"currency": "EUR", "netValue": -400, "taxes": []}}},
+			{"order": {"id": 1, "createdAt": "2025-01-10T10:00:00Z", "status": "FILLED", "side": "BUY", "ticker": "AAPL_US_EQ",
+			           "quantity": 5, "filledQuantity": 5, "currency": "USD"},
+			 "fill": {"id": 1, "filledAt": "2025-01-10T10:00:01Z", "price": 150, "quantity": 5,
+			          "walletImpact": {"currency": "EUR", "fxRate": 0.95, "netValue": -800, "taxes": []}}}],
+			"nextPagePath": null}`)
+	})
+	mux.HandleFunc("GET /api/v0/history/dividends", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"items": [{"ticker": "AAPL_US_EQ", "amount": 1.5, "currency": "EUR", "paidOn": "2025-05-15T00:00:00Z",
+			"quantity": 5, "type": "ORDINARY", "reference": "d1"}], "nextPagePath": null}`)
+	})
+	mux.HandleFunc("GET /api/v0/history/transactions", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"items": [
+			{"type": "DEPOSIT", "amount": 300, "currency": "EUR", "dateTime": "2025-02-01T09:00:00Z", "reference": "t2"},
+			{"type": "DEPOSIT", "amount": 1000, "currency": "EUR", "dateTime": "2025-01-05T09:00:00Z", "reference": "t1"}],
+			"nextPagePath": null}`)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	client, err := trading212.NewClient(srv.URL, "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// storedValuations stands in for the snapshot store, so --twr can chain sub-periods.
+type storedValuations []portfolio.Valuation
+
+func (v storedValuations) Valuations(context.Context, int64) ([]portfolio.Valuation, error) {
+	return v, nil
+}
+
+// TestPortfolioJSONMatchesSchema renders reports through every 'portfolio --json' path and checks
+// each against the published schema.
+func TestPortfolioJSONMatchesSchema(t *testing.T) {
+	from, to := "2025-01-01", "2025-06-30"
+	period := portfolio.PeriodRange{From: &from, To: &to}
+
+	tests := []struct {
+		name    string
+		period  portfolio.PeriodRange
+		opts    portfolio.PortfolioOptions
+		fields  []string
+		stored  bool // read valuations from snapshots
+		prepare func(*portfolio.Output)
+	}{
+		{name: "plain"},
+		{name: "fields", fields: []string{"ticker", "value", "fx_impact", "fx_pair"}},
+		{name: "fields with unavailable mwr", fields: []string{"ticker", "mwr"}},
+		{name: "twr without snapshots", opts: portfolio.PortfolioOptions{WithFlows: true}},
+		{name: "twr", opts: portfolio.PortfolioOptions{WithFlows: true}, stored: true},
+		{name: "mwr", opts: portfolio.PortfolioOptions{WithMWR: true}},
+		{name: "mwr fields", opts: portfolio.PortfolioOptions{WithMWR: true}, fields: []string{"ticker", "mwr", "pnl"}},
+		{name: "period", period: period, opts: portfolio.PortfolioOptions{WithFlows: true, WithMWR: true}},
+		{name: "raw data", opts: portfolio.PortfolioOptions{IncludeRaw: true}},
+		{
+			name: "nullable fx",
+			prepare: func(o *portfolio.Output) {
+				o.Summary.Derived.HoldingsFXImpact = nil
+				o.Summary.Derived.HoldingsPnLExclFX = nil
+				for i := range o.Holdings {
+					o.Holdings[i].FXImpact = nil
+				}
+			},
+		},
+		{
+			name: "empty account",
+			prepare: func(o *portfolio.Output) {
+				o.Holdings = []portfolio.HoldingRow{}
+				o.Allocation = []portfolio.AllocationRow{}
+			},
+		},
+	}
+
+	schema, err := portfolio.OutputSchema(portfolio.SchemaVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := fakeAccount(t)
+	svc := portfolio.NewService(client)
+	withSnapshots := portfolio.NewService(client, portfolio.WithValuations(storedValuations{
+		{At: time.Date(2024, 12, 31, 18, 0, 0, 0, time.Local), Value: 0},
+		{At: time.Date(2025, 3, 31, 18, 0, 0, 0, time.Local), Value: 1450},
+	}))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := svc
+			if tt.stored {
+				svc = withSnapshots
+			}
+			output, err := svc.GetPortfolio(context.Background(), tt.period, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.prepare != nil {
+				tt.prepare(output)
+			}
+			fields, err := ParseHoldingFields(tt.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := RenderPortfolioJSON(output, fields, &buf); err != nil {
+				t.Fatal(err)
+			}
+			if err := jsonschema.ValidateJSON(schema, buf.Bytes()); err != nil {
+				t.Errorf("%v\n%s", err, buf.Bytes())
+			}
+			if err := portfolio.ValidateOutput(buf.Bytes()); err != nil {
+				t.Errorf("ValidateOutput: %v", err)
+			}
+		})
+	}
+
+	t.Run("consolidated", func(t *testing.T) {
+		var accounts []portfolio.AccountOutput
+		for _, profile := range []string{"main", "isa"} {
+			output, err := svc.GetPortfolio(context.Background(), period, portfolio.PortfolioOptions{WithFlows: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			accounts = append(accounts, portfolio.AccountOutput{Profile: profile, Output: output})
+		}
+		output, err := portfolio.Consolidate(accounts, portfolio.NewFXRates("EUR"), time.Now(), period)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := RenderPortfolioJSON(output, nil, &buf); err != nil {
+			t.Fatal(err)
+		}
+		if err := jsonschema.ValidateJSON(schema, buf.Bytes()); err != nil {
+			t.Errorf("%v\n%s", err, buf.Bytes())
+		}
+	})
+}
+
+// TestPortfolioJSONSchemaRejectsDrift checks that the schema is strict enough to notice a
+// renamed or missing key.
+func TestPortfolioJSONSchemaRejectsDrift(t *testing.T) {
+	schema, err := portfolio.OutputSchema(portfolio.SchemaVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := portfolio.NewService(fakeAccount(t)).GetPortfolio(context.Background(), portfolio.PeriodRange{}, portfolio.PortfolioOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := RenderPortfolioJSON(output, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	doc := buf.Bytes()
+
+	for name, edit := range map[string][2]string{
+		"renamed holding key":  {`"marketValue"`, `"market_value"`},
+		"renamed summary key":  {`"currency":"EUR"`, `"ccy":"EUR"`},
+		"wrong schema version": {`"schemaVersion":`, `"schemaVersion":9`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			bad := bytes.Replace(doc, []byte(edit[0]), []byte(edit[1]), 1)
+			if bytes.Equal(bad, doc) {
+				t.Fatalf("fixture does not contain %s", edit[0])
+			}
+			if err := jsonschema.ValidateJSON(schema, bad); err == nil {
+				t.Errorf("accepted %s", bad)
+			}
+		})
+	}
+}
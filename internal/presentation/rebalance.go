@@ -0,0 +1,74 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+// RenderRebalanceText prints drift per target and the proposed trades, largest first.
+func RenderRebalanceText(r *portfolio.RebalanceOutput, w io.Writer) error {
+	var s strings.Builder
+
+	s.WriteString(fmt.Sprintf("Rebalance plan (%s, %s)\n", r.Mode, currencyLabel(r.Currency)))
+	s.WriteString(fmt.Sprintf("  holdings value: %.2f\n", r.HoldingsValue))
+	s.WriteString(fmt.Sprintf("  cash to invest: %.2f\n", r.Cash))
+	if r.MinTrade > 0 {
+		s.WriteString(fmt.Sprintf("  minimum trade: %.2f\n", r.MinTrade))
+	}
+	s.WriteString("\n")
+
+	s.WriteString(fmt.Sprintf("  %-12s %8s %8s %8s  %-6s %12s %14s\n", "ticker", "current", "target", "drift", "action", "amount", "shares"))
+	for _, row := range r.Rows {
+		shares := "-"
+		if row.Qty != nil {
+			shares = fmt.Sprintf("%.6g", *row.Qty)
+		}
+		amount := "-"
+		if row.Action != portfolio.ActionHold {
+			amount = fmt.Sprintf("%.2f", row.Amount)
+		}
+		s.WriteString(fmt.Sprintf("  %-12s %7.2f%% %7.2f%% %+7.2f%%  %-6s %12s %14s",
+			row.Ticker, bpsToPct(row.CurrentBps), bpsToPct(row.TargetBps), bpsToPct(row.DriftBps), row.Action, amount, shares))
+		var notes []string
+		if row.Group != "" {
+			notes = append(notes, "group "+row.Group)
+		}
+		if row.Note != "" {
+			notes = append(notes, row.Note)
+		}
+		if len(notes) > 0 {
+			s.WriteString("  (" + strings.Join(notes, "; ") + ")")
+		}
+		s.WriteString("\n")
+	}
+	s.WriteString("\n")
+
+	if len(r.Groups) > 0 {
+		s.WriteString("Groups\n")
+		for _, g := range r.Groups {
+			s.WriteString(fmt.Sprintf("  %-12s %7.2f%% %7.2f%% %+7.2f%%  buys %.2f | sells %.2f\n",
+				g.Name, bpsToPct(g.CurrentBps), bpsToPct(g.TargetBps), bpsToPct(g.DriftBps), g.Buys, g.Sells))
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString(fmt.Sprintf("Totals (%s)\n", currencyLabel(r.Currency)))
+	s.WriteString(fmt.Sprintf("  buys: %.2f\n", r.Totals.Buys))
+	s.WriteString(fmt.Sprintf("  sells: %.2f\n", r.Totals.Sells))
+	s.WriteString(fmt.Sprintf("  cash used (buys - sells): %.2f\n", r.Totals.CashUsed))
+	s.WriteString(fmt.Sprintf("  cash left: %.2f\n", r.Totals.CashLeft))
+
+	for _, warning := range r.Warnings {
+		s.WriteString(fmt.Sprintf("WARNING: %s\n", warning))
+	}
+
+	_, err := w.Write([]byte(s.String()))
+	return err
+}
+
+func bpsToPct(bps int) float64 {
+	return float64(bps) / 100
+}
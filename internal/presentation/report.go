@@ -0,0 +1,206 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+// reportView is the portfolio report prepared for the document renderers (Markdown, HTML): values
+// are already formatted so both renderers show the same numbers.
+type reportView struct {
+	Title      string
+	ReportDate string
+	Period     string
+	Currency   string
+	Note       string
+	Sections   []reportSection
+	Accounts   []reportAccount
+	Allocation []reportAllocation
+	Holdings   []reportHolding
+	Warnings   []string
+}
+
+type reportSection struct {
+	Title string
+	Rows  []reportValue
+}
+
+type reportValue struct {
+	Label string
+	Value string
+	Class string // pos, neg or empty
+}
+
+type reportAccount struct {
+	Profile   string
+	AccountID string
+	Total     string // account currency
+	Converted string // reporting currency, with rate
+	Return    string
+	TWR       string
+	MWR       string
+}
+
+type reportAllocation struct {
+	Ticker string
+	Value  string
+	Pct    string
+	BarPct float64 // share of the largest position, 0-100, for bar widths
+}
+
+type reportHolding struct {
+	Name         string
+	Ticker       string
+	ISIN         string
+	Qty          string
+	AvgPrice     string
+	CurrentPrice string
+	Invested     string
+	MarketValue  string
+	PnL          string
+	PnLClass     string
+	FXImpact     string
+	Weight       string
+	MWR          string
+	Accounts     string
+}
+
+func newReportView(output *portfolio.Output) reportView {
+	d := output.Summary.Derived
+	ccy := output.Summary.Currency
+	money := func(v float64) string { return fmt.Sprintf("%.2f %s", v, ccy) }
+	pct := func(v float64) string { return fmt.Sprintf("%.2f%%", v) }
+
+	v := reportView{
+		Title:      "Portfolio report " + output.Report.ReportDate,
+		ReportDate: output.Report.ReportDate,
+		Period:     formatPeriodLabel(output.Report.Period),
+		Currency:   ccy,
+	}
+	if !isAllTime(output.Report.Period) {
+		v.Note = "Holdings metrics reflect executed positions; pie cash is a snapshot at period end (uninvested)."
+	}
+
+	perf := reportSection{Title: "Holdings performance", Rows: []reportValue{
+		{Label: "Cost basis", Value: money(d.HoldingsCost)},
+		{Label: "Unrealized PnL", Value: money(d.HoldingsPnL), Class: signClass(d.HoldingsPnL)},
+	}}
+	if d.HoldingsFXImpact != nil && d.HoldingsPnLExclFX != nil {
+		perf.Rows = append(perf.Rows,
+			reportValue{Label: "FX impact", Value: money(*d.HoldingsFXImpact), Class: signClass(*d.HoldingsFXImpact)},
+			reportValue{Label: "Unrealized PnL excl. FX", Value: money(*d.HoldingsPnLExclFX), Class: signClass(*d.HoldingsPnLExclFX)},
+		)
+	} else {
+		perf.Rows = append(perf.Rows, reportValue{Label: "FX impact", Value: "n/a"})
+	}
+	perf.Rows = append(perf.Rows, reportValue{Label: "Return", Value: pct(d.HoldingsReturnPct), Class: signClass(d.HoldingsReturnPct)})
+	twrLabel := "TWR (est.)"
+	if portfolio.FlowAdjustedTWR(d.TWRMethod) {
+		twrLabel = "TWR (flow-adjusted)"
+	}
+	perf.Rows = append(perf.Rows, reportValue{Label: twrLabel, Value: pct(d.TWRPctEst), Class: signClass(d.TWRPctEst)})
+	if d.MWRPct != nil {
+		perf.Rows = append(perf.Rows, reportValue{Label: "MWR (annualized)", Value: pct(*d.MWRPct), Class: signClass(*d.MWRPct)})
+	}
+
+	v.Sections = []reportSection{
+		{Title: "Account total", Rows: []reportValue{
+			{Label: "Holdings value", Value: money(d.HoldingsValue)},
+			{Label: "Pie cash (uninvested)", Value: money(d.PieCash)},
+			{Label: "Investments allocated", Value: money(d.Allocated)},
+			{Label: "Free cash", Value: money(d.FreeCash)},
+			{Label: "Account total", Value: money(d.AccountTotal)},
+		}},
+		perf,
+	}
+	if flows := output.PeriodFlows; flows != nil && flows.Available {
+		v.Sections = append(v.Sections, reportSection{Title: "Period flows (executed trades)", Rows: []reportValue{
+			{Label: "Orders", Value: fmt.Sprintf("%d", flows.OrderCount)},
+			{Label: "Buys", Value: money(flows.Buys)},
+			{Label: "Sells", Value: money(flows.Sells)},
+			{Label: "Fees", Value: money(flows.Fees)},
+			{Label: "Net (buys - sells)", Value: money(flows.Net)},
+		}})
+	}
+
+	for _, a := range output.Accounts {
+		ra := reportAccount{
+			Profile:   a.Profile,
+			AccountID: fmt.Sprintf("%d", a.AccountID),
+			Total:     fmt.Sprintf("%.2f %s", a.Derived.AccountTotal, a.Currency),
+			Converted: money(a.AccountTotal),
+			Return:    pct(a.Derived.HoldingsReturnPct),
+			TWR:       pct(a.Derived.TWRPctEst),
+			MWR:       "n/a",
+		}
+		if a.FXSource != portfolio.FXSourceSame {
+			ra.Converted += fmt.Sprintf(" (@ %.6g, %s)", a.FXRate, a.FXSource)
+		}
+		if a.Derived.MWRPct != nil {
+			ra.MWR = pct(*a.Derived.MWRPct)
+		}
+		v.Accounts = append(v.Accounts, ra)
+	}
+
+	var largest float64
+	for _, row := range output.Allocation {
+		largest = max(largest, row.HoldingsPct)
+	}
+	for _, row := range output.Allocation {
+		ra := reportAllocation{Ticker: row.Ticker, Value: money(row.MarketValue), Pct: pct(row.HoldingsPct)}
+		if largest > 0 {
+			ra.BarPct = portfolio.Round(row.HoldingsPct/largest*100, 2)
+		}
+		v.Allocation = append(v.Allocation, ra)
+	}
+
+	for _, h := range output.Holdings {
+		rh := reportHolding{
+			Name:         h.Name,
+			Ticker:       h.Ticker,
+			ISIN:         h.ISIN,
+			Qty:          fmt.Sprintf("%.6g", h.Qty),
+			AvgPrice:     strings.TrimSpace(fmt.Sprintf("%.6g %s", h.AvgPricePaid, h.InstrumentCurrency)),
+			CurrentPrice: strings.TrimSpace(fmt.Sprintf("%.6g %s", h.CurrentPrice, h.InstrumentCurrency)),
+			Invested:     money(h.Invested),
+			MarketValue:  money(h.MarketValue),
+			PnL:          money(h.UnrealizedPnL),
+			PnLClass:     signClass(h.UnrealizedPnL),
+			FXImpact:     "n/a",
+			Weight:       pct(h.HoldingsPct),
+			MWR:          "n/a",
+		}
+		if h.FXImpact != nil {
+			rh.FXImpact = money(*h.FXImpact)
+		}
+		if h.MWRPct != nil {
+			rh.MWR = pct(*h.MWRPct)
+		}
+		if len(h.Accounts) > 0 {
+			parts := make([]string, 0, len(h.Accounts))
+			for _, a := range h.Accounts {
+				parts = append(parts, fmt.Sprintf("%s %.6g", a.Profile, a.Qty))
+			}
+			rh.Accounts = strings.Join(parts, ", ")
+		}
+		v.Holdings = append(v.Holdings, rh)
+	}
+
+	v.Warnings = append(v.Warnings, output.Summary.Reconciliation.Warnings...)
+	if flows := output.PeriodFlows; flows != nil {
+		v.Warnings = append(v.Warnings, flows.Warnings...)
+	}
+	return v
+}
+
+func signClass(v float64) string {
+	switch {
+	case v > 0:
+		return "pos"
+	case v < 0:
+		return "neg"
+	}
+	return ""
+}
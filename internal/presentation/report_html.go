@@ -0,0 +1,109 @@
+package presentation
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+// RenderPortfolioHTML writes the report as a single HTML file: inline CSS, no scripts, no external assets.
+func RenderPortfolioHTML(output *portfolio.Output, w io.Writer) error {
+	return reportHTMLTemplate.Execute(w, newReportView(output))
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+  body { font: 14px/1.45 -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; margin: 2rem auto; max-width: 1100px; padding: 0 1rem; }
+  h1 { font-size: 1.6rem; margin-bottom: .25rem; }
+  h2 { font-size: 1.15rem; margin-top: 2rem; border-bottom: 1px solid #d0d7de; padding-bottom: .3rem; }
+  .meta { color: #656d76; margin: 0 0 1rem; }
+  .note { background: #f6f8fa; border-left: 4px solid #d0d7de; padding: .5rem .75rem; }
+  .cards { display: flex; flex-wrap: wrap; gap: 1.5rem; }
+  .cards section { flex: 1 1 300px; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { padding: .35rem .6rem; border-bottom: 1px solid #eaeef2; text-align: left; white-space: nowrap; }
+  th { background: #f6f8fa; font-weight: 600; }
+  td.num, th.num { text-align: right; font-variant-numeric: tabular-nums; }
+  .pos { color: #1a7f37; }
+  .neg { color: #cf222e; }
+  .bar { background: #eaeef2; border-radius: 3px; height: .7rem; min-width: 160px; }
+  .bar span { display: block; height: 100%; border-radius: 3px; background: #0969da; }
+  .wrap { overflow-x: auto; }
+  .warnings li { color: #9a6700; }
+  footer { color: #656d76; margin-top: 2rem; font-size: .85rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">Reporting period: {{.Period}} &middot; Currency: {{.Currency}}</p>
+{{- if .Note}}
+<p class="note">{{.Note}}</p>
+{{- end}}
+
+<div class="cards">
+{{- range .Sections}}
+<section>
+<h2>{{.Title}}</h2>
+<table>
+{{- range .Rows}}
+<tr><td>{{.Label}}</td><td class="num{{with .Class}} {{.}}{{end}}">{{.Value}}</td></tr>
+{{- end}}
+</table>
+</section>
+{{- end}}
+</div>
+{{- if .Accounts}}
+
+<h2>Accounts</h2>
+<div class="wrap"><table>
+<tr><th>Profile</th><th>Account</th><th class="num">Total</th><th class="num">In {{.Currency}}</th><th class="num">Return</th><th class="num">TWR</th><th class="num">MWR</th></tr>
+{{- range .Accounts}}
+<tr><td>{{.Profile}}</td><td>{{.AccountID}}</td><td class="num">{{.Total}}</td><td class="num">{{.Converted}}</td><td class="num">{{.Return}}</td><td class="num">{{.TWR}}</td><td class="num">{{.MWR}}</td></tr>
+{{- end}}
+</table></div>
+{{- end}}
+
+<h2>Allocation</h2>
+{{- if .Allocation}}
+<table>
+<tr><th>Ticker</th><th class="num">Value</th><th class="num">Share</th><th></th></tr>
+{{- range .Allocation}}
+<tr><td>{{.Ticker}}</td><td class="num">{{.Value}}</td><td class="num">{{.Pct}}</td><td style="width: 40%"><div class="bar"><span style="width: {{.BarPct}}%"></span></div></td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No holdings.</p>
+{{- end}}
+
+<h2>Holdings</h2>
+{{- if .Holdings}}
+<div class="wrap"><table>
+<tr><th>Name</th><th>Ticker</th><th>ISIN</th><th class="num">Qty</th><th class="num">Avg price</th><th class="num">Price</th><th class="num">Invested</th><th class="num">Value</th><th class="num">uPnL</th><th class="num">FX impact</th><th class="num">Share</th><th class="num">MWR</th>{{if .Accounts}}<th>Accounts</th>{{end}}</tr>
+{{- $withAccounts := .Accounts}}
+{{- range .Holdings}}
+<tr><td>{{.Name}}</td><td>{{.Ticker}}</td><td>{{.ISIN}}</td><td class="num">{{.Qty}}</td><td class="num">{{.AvgPrice}}</td><td class="num">{{.CurrentPrice}}</td><td class="num">{{.Invested}}</td><td class="num">{{.MarketValue}}</td><td class="num{{with .PnLClass}} {{.}}{{end}}">{{.PnL}}</td><td class="num">{{.FXImpact}}</td><td class="num">{{.Weight}}</td><td class="num">{{.MWR}}</td>{{if $withAccounts}}<td>{{.Accounts}}</td>{{end}}</tr>
+{{- end}}
+</table></div>
+{{- else}}
+<p>No open positions.</p>
+{{- end}}
+{{- if .Warnings}}
+
+<h2>Reconciliation warnings</h2>
+<ul class="warnings">
+{{- range .Warnings}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+
+<footer>Generated by folio212 on {{.ReportDate}}.</footer>
+</body>
+</html>
+`))
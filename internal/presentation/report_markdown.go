@@ -0,0 +1,110 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+// reportBarWidth is the width of Markdown allocation bars in characters.
+const reportBarWidth = 20
+
+// RenderPortfolioMarkdown writes the report as a self-contained Markdown document (GitHub-flavoured tables).
+func RenderPortfolioMarkdown(output *portfolio.Output, w io.Writer) error {
+	v := newReportView(output)
+	var s strings.Builder
+
+	fmt.Fprintf(&s, "# %s\n\n", mdEscape(v.Title))
+	fmt.Fprintf(&s, "- Report date: %s\n", v.ReportDate)
+	fmt.Fprintf(&s, "- Reporting period: %s\n", v.Period)
+	fmt.Fprintf(&s, "- Currency: %s\n", v.Currency)
+	if v.Note != "" {
+		fmt.Fprintf(&s, "\n> %s\n", v.Note)
+	}
+	s.WriteString("\n")
+
+	for _, sec := range v.Sections {
+		fmt.Fprintf(&s, "## %s\n\n| | |\n|---|---:|\n", sec.Title)
+		for _, r := range sec.Rows {
+			fmt.Fprintf(&s, "| %s | %s |\n", mdEscape(r.Label), mdEscape(r.Value))
+		}
+		s.WriteString("\n")
+	}
+
+	if len(v.Accounts) > 0 {
+		fmt.Fprintf(&s, "## Accounts\n\n| Profile | Account | Total | In %s | Return | TWR | MWR |\n|---|---|---:|---:|---:|---:|---:|\n", v.Currency)
+		for _, a := range v.Accounts {
+			fmt.Fprintf(&s, "| %s | %s | %s | %s | %s | %s | %s |\n",
+				mdEscape(a.Profile), a.AccountID, a.Total, mdEscape(a.Converted), a.Return, a.TWR, a.MWR)
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString("## Allocation\n\n")
+	if len(v.Allocation) == 0 {
+		s.WriteString("No holdings.\n\n")
+	} else {
+		s.WriteString("| Ticker | Value | Share | |\n|---|---:|---:|---|\n")
+		for _, a := range v.Allocation {
+			fmt.Fprintf(&s, "| %s | %s | %s | %s |\n", mdEscape(a.Ticker), a.Value, a.Pct, mdBar(a.BarPct))
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString("## Holdings\n\n")
+	if len(v.Holdings) == 0 {
+		s.WriteString("No open positions.\n\n")
+	} else {
+		withAccounts := len(v.Accounts) > 0
+		s.WriteString("| Name | Ticker | ISIN | Qty | Avg price | Price | Invested | Value | uPnL | FX impact | Share | MWR |")
+		if withAccounts {
+			s.WriteString(" Accounts |")
+		}
+		s.WriteString("\n|---|---|---|---:|---:|---:|---:|---:|---:|---:|---:|---:|")
+		if withAccounts {
+			s.WriteString("---|")
+		}
+		s.WriteString("\n")
+		for _, h := range v.Holdings {
+			fmt.Fprintf(&s, "| %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s | %s |",
+				mdEscape(h.Name), mdEscape(h.Ticker), h.ISIN, h.Qty, h.AvgPrice, h.CurrentPrice,
+				h.Invested, h.MarketValue, h.PnL, h.FXImpact, h.Weight, h.MWR)
+			if withAccounts {
+				fmt.Fprintf(&s, " %s |", mdEscape(h.Accounts))
+			}
+			s.WriteString("\n")
+		}
+		s.WriteString("\n")
+	}
+
+	if len(v.Warnings) > 0 {
+		s.WriteString("## Reconciliation warnings\n\n")
+		for _, warning := range v.Warnings {
+			fmt.Fprintf(&s, "- %s\n", mdEscape(warning))
+		}
+		s.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, strings.TrimRight(s.String(), "\n")+"\n")
+	return err
+}
+
+// mdBar draws a bar of up to reportBarWidth block characters.
+func mdBar(pct float64) string {
+	n := int(math.Round(pct / 100 * reportBarWidth))
+	if n == 0 && pct > 0 {
+		n = 1
+	}
+	return strings.Repeat("█", n)
+}
+
+// mdEscape keeps table cells intact (pipes split cells, newlines end rows) and stops names from
+// being rendered as inline HTML.
+var mdEscaper = strings.NewReplacer("|", `\|`, "\n", " ", "<", "&lt;", ">", "&gt;")
+
+func mdEscape(s string) string {
+	return mdEscaper.Replace(s)
+}
@@ -0,0 +1,51 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nezdemkovski/folio212/internal/infrastructure/snapshots"
+)
+
+// SnapshotSummary is the compact listing shape for a stored snapshot.
+type SnapshotSummary struct {
+	ID           string  `json:"id"`
+	SavedAt      string  `json:"savedAt"` // RFC3339
+	AccountID    int64   `json:"accountId"`
+	Currency     string  `json:"currency"`
+	AccountTotal float64 `json:"accountTotal"`
+	Holdings     int     `json:"holdings"`
+}
+
+func NewSnapshotSummary(r snapshots.Record) SnapshotSummary {
+	sum := SnapshotSummary{
+		ID:        r.ID,
+		SavedAt:   r.SavedAt.Format(time.RFC3339),
+		AccountID: r.AccountID,
+	}
+	if r.Output != nil {
+		sum.Currency = r.Output.Summary.Currency
+		sum.AccountTotal = r.Output.Summary.Derived.AccountTotal
+		sum.Holdings = len(r.Output.Holdings)
+	}
+	return sum
+}
+
+func RenderSnapshotList(records []snapshots.Record, w io.Writer) error {
+	var s strings.Builder
+
+	if len(records) == 0 {
+		s.WriteString("No snapshots stored yet. Run 'folio212 snapshot save' to record one.\n")
+	} else {
+		for _, r := range records {
+			sum := NewSnapshotSummary(r)
+			s.WriteString(fmt.Sprintf("%-28s %s  account total: %.2f %s  (%d holdings)\n",
+				sum.ID, r.SavedAt.Local().Format("2006-01-02 15:04"), sum.AccountTotal, sum.Currency, sum.Holdings))
+		}
+	}
+
+	_, err := w.Write([]byte(s.String()))
+	return err
+}
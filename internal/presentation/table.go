@@ -0,0 +1,218 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"charm.land/lipgloss/v2"
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+	"github.com/nezdemkovski/folio212/internal/shared/ui"
+)
+
+// TableOptions controls RenderPortfolioTable.
+type TableOptions struct {
+	Width int  // terminal width; columns are shrunk or dropped to fit (0 = no limit)
+	Color bool // use the ui palette (false for pipes, files and NO_COLOR)
+}
+
+type tableColumn struct {
+	header string
+	right  bool
+	max    int // 0 = no limit; cells are truncated
+	min    int // shrinkable down to this width before columns are dropped
+	drop   int // columns are dropped in ascending order when the table does not fit; 0 = never
+	cell   func(h portfolio.HoldingRow) string
+	signed func(h portfolio.HoldingRow) float64 // colours the cell green/red when set
+}
+
+var portfolioTableColumns = []tableColumn{
+	{header: "ticker", cell: func(h portfolio.HoldingRow) string { return h.Ticker }},
+	{header: "name", max: 28, min: 10, drop: 4, cell: func(h portfolio.HoldingRow) string { return h.Name }},
+	{header: "qty", right: true, drop: 3, cell: func(h portfolio.HoldingRow) string { return formatQty(h.Qty) }},
+	{header: "avg", right: true, drop: 1, cell: func(h portfolio.HoldingRow) string { return formatPrice(h.AvgPricePaid) }},
+	{header: "price", right: true, drop: 2, cell: func(h portfolio.HoldingRow) string { return formatPrice(h.CurrentPrice) }},
+	{header: "value", right: true, cell: func(h portfolio.HoldingRow) string { return tableMoney(h.MarketValue) }},
+	{
+		header: "uPnL", right: true,
+		cell:   func(h portfolio.HoldingRow) string { return formatTemplateNumber(h.UnrealizedPnL, 2, true, true) },
+		signed: func(h portfolio.HoldingRow) float64 { return h.UnrealizedPnL },
+	},
+	{
+		header: "return", right: true, drop: 5,
+		cell:   func(h portfolio.HoldingRow) string { return fmt.Sprintf("%+.2f%%", portfolio.HoldingReturnPct(h)) },
+		signed: func(h portfolio.HoldingRow) float64 { return portfolio.HoldingReturnPct(h) },
+	},
+	{header: "weight", right: true, cell: func(h portfolio.HoldingRow) string { return fmt.Sprintf("%.2f%%", h.HoldingsPct) }},
+}
+
+// tableGap separates columns.
+const tableGap = "  "
+
+// RenderPortfolioTable prints a one-line account summary and one aligned row per holding.
+// Positions with nothing tradable (e.g. fully in pies) are dimmed.
+func RenderPortfolioTable(output *portfolio.Output, opts TableOptions, w io.Writer) error {
+	paint := func(style lipgloss.Style, s string) string {
+		if !opts.Color {
+			return s
+		}
+		return style.Render(s)
+	}
+	d := output.Summary.Derived
+	var s strings.Builder
+
+	s.WriteString(paint(ui.Label, "account ") + paint(ui.Value, tableMoney(d.AccountTotal)+" "+output.Summary.Currency))
+	s.WriteString(paint(ui.Label, "  holdings ") + paint(ui.Value, tableMoney(d.HoldingsValue)))
+	s.WriteString(paint(ui.Label, "  cash ") + paint(ui.Value, tableMoney(d.FreeCash)))
+	s.WriteString(paint(ui.Label, "  uPnL ") + paint(signedStyle(d.HoldingsPnL),
+		fmt.Sprintf("%s (%+.2f%%)", formatTemplateNumber(d.HoldingsPnL, 2, true, true), d.HoldingsReturnPct)))
+	s.WriteString("\n")
+	s.WriteString(paint(ui.Meta, fmt.Sprintf("%s · %s", output.Report.ReportDate, formatPeriodLabel(output.Report.Period))) + "\n\n")
+
+	if len(output.Holdings) == 0 {
+		s.WriteString(paint(ui.Meta, "No open positions.") + "\n")
+	} else {
+		cols, widths := fitTableColumns(output.Holdings, opts.Width)
+
+		header := make([]string, len(cols))
+		for i, c := range cols {
+			header[i] = paint(ui.Label, pad(c.header, widths[i], c.right))
+		}
+		s.WriteString(strings.TrimRight(strings.Join(header, tableGap), " ") + "\n")
+
+		var totalValue, totalPnL float64
+		for _, h := range output.Holdings {
+			totalValue += h.MarketValue
+			totalPnL += h.UnrealizedPnL
+			dim := h.Qty > 0 && h.TradableQty == 0
+			cells := make([]string, len(cols))
+			for i, c := range cols {
+				text := pad(truncate(c.cell(h), widths[i]), widths[i], c.right)
+				switch {
+				case dim:
+					text = paint(ui.DimStyle, text)
+				case c.signed != nil:
+					text = paint(signedStyle(c.signed(h)), text)
+				}
+				cells[i] = text
+			}
+			s.WriteString(strings.TrimRight(strings.Join(cells, tableGap), " ") + "\n")
+		}
+
+		if len(output.Holdings) > 1 {
+			totals := make([]string, len(cols))
+			for i, c := range cols {
+				var text string
+				switch c.header {
+				case "ticker":
+					text = "total"
+				case "value":
+					text = tableMoney(totalValue)
+				case "uPnL":
+					text = formatTemplateNumber(totalPnL, 2, true, true)
+				}
+				text = pad(truncate(text, widths[i]), widths[i], c.right)
+				switch {
+				case c.header == "uPnL":
+					text = paint(signedStyle(totalPnL), text)
+				case strings.TrimSpace(text) != "":
+					text = paint(ui.Section, text)
+				}
+				totals[i] = text
+			}
+			s.WriteString(strings.TrimRight(strings.Join(totals, tableGap), " ") + "\n")
+		}
+	}
+
+	warnings := append([]string{}, output.Summary.Reconciliation.Warnings...)
+	if output.PeriodFlows != nil {
+		warnings = append(warnings, output.PeriodFlows.Warnings...)
+	}
+	if len(warnings) > 0 {
+		s.WriteString("\n")
+		for _, warning := range warnings {
+			if opts.Color {
+				s.WriteString(ui.StatusWarning(warning) + "\n")
+			} else {
+				s.WriteString(ui.SymbolWarning + " " + warning + "\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, s.String())
+	return err
+}
+
+// fitTableColumns returns the columns to show and their widths. When the table is wider than
+// width, the name column shrinks first and then columns are dropped by priority.
+func fitTableColumns(rows []portfolio.HoldingRow, width int) ([]tableColumn, []int) {
+	cols := append([]tableColumn(nil), portfolioTableColumns...)
+	widths := make([]int, len(cols))
+	for i, c := range cols {
+		widths[i] = utf8.RuneCountInString(c.header)
+		for _, h := range rows {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c.cell(h)))
+		}
+		if c.max > 0 {
+			widths[i] = min(widths[i], c.max)
+		}
+	}
+	// The totals row must fit in value and uPnL as well.
+	var totalValue, totalPnL float64
+	for _, h := range rows {
+		totalValue += h.MarketValue
+		totalPnL += h.UnrealizedPnL
+	}
+	for i, c := range cols {
+		switch c.header {
+		case "value":
+			widths[i] = max(widths[i], utf8.RuneCountInString(tableMoney(totalValue)))
+		case "uPnL":
+			widths[i] = max(widths[i], utf8.RuneCountInString(formatTemplateNumber(totalPnL, 2, true, true)))
+		}
+	}
+	if width <= 0 {
+		return cols, widths
+	}
+
+	total := func() int {
+		n := len(tableGap) * (len(widths) - 1)
+		for _, w := range widths {
+			n += w
+		}
+		return n
+	}
+	for i, c := range cols {
+		if over := total() - width; over > 0 && c.min > 0 && widths[i] > c.min {
+			widths[i] = max(c.min, widths[i]-over)
+		}
+	}
+	for total() > width {
+		drop := -1
+		for i, c := range cols {
+			if c.drop > 0 && (drop < 0 || c.drop < cols[drop].drop) {
+				drop = i
+			}
+		}
+		if drop < 0 {
+			break // only essential columns left; let the terminal wrap
+		}
+		cols = append(cols[:drop], cols[drop+1:]...)
+		widths = append(widths[:drop], widths[drop+1:]...)
+	}
+	return cols, widths
+}
+
+func tableMoney(v float64) string {
+	return formatTemplateNumber(v, 2, true, false)
+}
+
+// formatPrice keeps sub-unit prices (e.g. penny stocks) readable.
+func formatPrice(v float64) string {
+	if v != 0 && math.Abs(v) < 1 {
+		return fmt.Sprintf("%.4f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
@@ -0,0 +1,195 @@
+package presentation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+// ParseOutputTemplate parses a user template: spec is either inline template text (anything that
+// contains "{{") or the path of a template file.
+func ParseOutputTemplate(spec string) (*template.Template, error) {
+	name, text := "template", spec
+	if !strings.Contains(spec, "{{") {
+		b, err := os.ReadFile(spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		name, text = spec, string(b)
+	}
+	t, err := template.New(name).Funcs(TemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return t, nil
+}
+
+// RenderPortfolioTemplate executes a user template with the report (the same data as --json) as dot.
+func RenderPortfolioTemplate(output *portfolio.Output, t *template.Template, w io.Writer) error {
+	if err := t.Execute(w, output); err != nil {
+		return fmt.Errorf("template: %w", err)
+	}
+	return nil
+}
+
+// TemplateFuncs are the helpers available to user templates. Numeric helpers accept numbers and
+// number pointers (optional report fields); a nil pointer renders as "n/a".
+var TemplateFuncs = template.FuncMap{
+	"money":        func(v any) string { return formatTemplateNumber(v, 2, true, false) },
+	"num":          func(decimals int, v any) string { return formatTemplateNumber(v, decimals, false, false) },
+	"signed":       func(v any) string { return formatTemplateNumber(v, 2, true, true) },
+	"pct":          func(v any) string { return suffix(formatTemplateNumber(v, 2, false, false), "%") },
+	"signedPct":    func(v any) string { return suffix(formatTemplateNumber(v, 2, false, true), "%") },
+	"bps":          func(v any) string { return suffix(formatTemplateNumber(v, 0, false, true), " bps") },
+	"symbol":       currencySymbol,
+	"padLeft":      func(width int, v any) string { return pad(fmt.Sprint(v), width, true) },
+	"padRight":     func(width int, v any) string { return pad(fmt.Sprint(v), width, false) },
+	"sortHoldings": sortHoldingsFunc,
+	"top": func(n int, rows any) (any, error) {
+		switch r := rows.(type) {
+		case []portfolio.HoldingRow:
+			return r[:min(max(n, 0), len(r))], nil
+		case []portfolio.AllocationRow:
+			return r[:min(max(n, 0), len(r))], nil
+		}
+		return nil, fmt.Errorf("top: unsupported rows %T", rows)
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+	"default": func(fallback, v any) any {
+		if v == nil || reflect.ValueOf(v).IsZero() {
+			return fallback
+		}
+		return v
+	},
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// sortHoldingsFunc returns a sorted copy: {{ range sortHoldings "pnl" .Holdings }}. A leading "-"
+// reverses the order ("-value" is smallest first).
+func sortHoldingsFunc(key string, rows []portfolio.HoldingRow) ([]portfolio.HoldingRow, error) {
+	reverse := strings.HasPrefix(key, "-")
+	k, err := portfolio.ParseHoldingSortKey(strings.TrimPrefix(key, "-"))
+	if err != nil {
+		return nil, err
+	}
+	out := slices.Clone(rows)
+	portfolio.SortHoldings(out, k, reverse)
+	return out, nil
+}
+
+func formatTemplateNumber(v any, decimals int, grouped, signed bool) string {
+	f, ok := templateFloat(v)
+	if !ok {
+		return "n/a"
+	}
+	s := strconv.FormatFloat(math.Abs(f), 'f', decimals, 64)
+	if grouped {
+		s = groupThousands(s)
+	}
+	switch {
+	case f < 0 && strings.Trim(s, "0.,") != "":
+		s = "-" + s
+	case signed && f > 0 && strings.Trim(s, "0.,") != "":
+		s = "+" + s
+	}
+	return s
+}
+
+func templateFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case *float64:
+		if n == nil {
+			return 0, false
+		}
+		return *n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case *int:
+		if n == nil {
+			return 0, false
+		}
+		return float64(*n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// groupThousands inserts "," between groups of three integer digits: 1234567.89 -> 1,234,567.89.
+func groupThousands(s string) string {
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if hasFrac {
+		b.WriteString("." + frac)
+	}
+	return b.String()
+}
+
+func suffix(s, sfx string) string {
+	if s == "n/a" {
+		return s
+	}
+	return s + sfx
+}
+
+func pad(s string, width int, left bool) string {
+	n := width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		return s
+	}
+	if left {
+		return strings.Repeat(" ", n) + s
+	}
+	return s + strings.Repeat(" ", n)
+}
+
+var currencySymbols = map[string]string{
+	"EUR": "€",
+	"USD": "$",
+	"GBP": "£",
+	"GBX": "p",
+	"CHF": "CHF",
+	"JPY": "¥",
+	"PLN": "zł",
+	"CZK": "Kč",
+	"SEK": "kr",
+	"NOK": "kr",
+	"DKK": "kr",
+	"CAD": "C$",
+	"AUD": "A$",
+	"HUF": "Ft",
+	"RON": "lei",
+}
+
+// currencySymbol returns the symbol for an ISO code, or the code itself when there is none.
+func currencySymbol(code string) string {
+	if s, ok := currencySymbols[strings.ToUpper(code)]; ok {
+		return s
+	}
+	return code
+}
@@ -0,0 +1,93 @@
+package presentation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+func RenderTransactionsText(output *portfolio.TransactionsOutput, w io.Writer) error {
+	var s strings.Builder
+
+	s.WriteString(fmt.Sprintf("Report date: %s\n", output.Report.ReportDate))
+	s.WriteString(fmt.Sprintf("Reporting period: %s\n\n", formatPeriodLabel(output.Report.Period)))
+
+	s.WriteString(fmt.Sprintf("Cash transactions (%s)\n", currencyLabel(output.Currency)))
+	s.WriteString(fmt.Sprintf("  deposits: %.2f\n", output.Totals.Deposits))
+	s.WriteString(fmt.Sprintf("  withdrawals: %.2f\n", output.Totals.Withdrawals))
+	s.WriteString(fmt.Sprintf("  net deposits: %.2f\n", output.Totals.NetDeposits))
+	s.WriteString(fmt.Sprintf("  interest: %.2f\n", output.Totals.Interest))
+	s.WriteString(fmt.Sprintf("  fees: %.2f\n\n", output.Totals.Fees))
+
+	if len(output.Totals.ByType) > 0 {
+		s.WriteString("By type:\n")
+		for _, t := range output.Totals.ByType {
+			s.WriteString(fmt.Sprintf("  %-12s %10.2f  (%d)\n", t.Type, t.Amount, t.Count))
+		}
+		s.WriteString("\n")
+	}
+
+	if len(output.Transactions) == 0 {
+		s.WriteString("No transactions in this period.\n")
+	} else {
+		for _, t := range output.Transactions {
+			s.WriteString(fmt.Sprintf("%s  %-12s %10.2f %s\n", dateOnly(t.DateTime), t.Type, t.Amount, t.Currency))
+		}
+	}
+
+	_, err := w.Write([]byte(s.String()))
+	return err
+}
+
+// transactionTotalPrefix marks the per-type total rows that follow the transactions in CSV output.
+const transactionTotalPrefix = "total_"
+
+// RenderTransactionsCSV writes one row per transaction with a stable header, followed by one row
+// per transaction type with the period total: type "total_<type>" (e.g. total_deposit) and an
+// empty date_time.
+func RenderTransactionsCSV(output *portfolio.TransactionsOutput, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"date_time", "type", "raw_type", "amount", "currency", "reference"}); err != nil {
+		return err
+	}
+	for _, t := range output.Transactions {
+		record := []string{
+			t.DateTime,
+			string(t.Type),
+			t.RawType,
+			strconv.FormatFloat(t.Amount, 'f', 2, 64),
+			t.Currency,
+			t.Reference,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	for _, t := range output.Totals.ByType {
+		record := []string{
+			"",
+			transactionTotalPrefix + string(t.Type),
+			"",
+			strconv.FormatFloat(t.Amount, 'f', 2, 64),
+			output.Currency,
+			"",
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// dateOnly trims an RFC3339 timestamp to its YYYY-MM-DD prefix.
+func dateOnly(ts string) string {
+	if len(ts) >= len("2006-01-02") {
+		return ts[:len("2006-01-02")]
+	}
+	return ts
+}
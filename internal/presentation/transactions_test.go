@@ -0,0 +1,50 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/nezdemkovski/folio212/internal/domain/portfolio"
+)
+
+func TestRenderTransactionsCSVTotals(t *testing.T) {
+	rows := []portfolio.TransactionRow{
+		{DateTime: "2025-03-02T10:00:00Z", Type: portfolio.TxDeposit, RawType: "DEPOSIT", Amount: 500, Currency: "EUR"},
+		{DateTime: "2025-03-01T10:00:00Z", Type: portfolio.TxFee, RawType: "FEE", Amount: -1.5, Currency: "EUR"},
+		{DateTime: "2025-02-01T10:00:00Z", Type: portfolio.TxDeposit, RawType: "DEPOSIT", Amount: 250, Currency: "EUR"},
+	}
+	output := &portfolio.TransactionsOutput{
+		Currency:     "EUR",
+		Totals:       portfolio.SummarizeTransactions(rows),
+		Transactions: rows,
+	}
+
+	var buf bytes.Buffer
+	if err := RenderTransactionsCSV(output, &buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"date_time", "type", "raw_type", "amount", "currency", "reference"},
+		{"2025-03-02T10:00:00Z", "deposit", "DEPOSIT", "500.00", "EUR", ""},
+		{"2025-03-01T10:00:00Z", "fee", "FEE", "-1.50", "EUR", ""},
+		{"2025-02-01T10:00:00Z", "deposit", "DEPOSIT", "250.00", "EUR", ""},
+		{"", "total_deposit", "", "750.00", "EUR", ""},
+		{"", "total_fee", "", "-1.50", "EUR", ""},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d:\n%v", len(records), len(want), records)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if records[i][j] != want[i][j] {
+				t.Errorf("record %d = %v, want %v", i, records[i], want[i])
+				break
+			}
+		}
+	}
+}
@@ -9,3 +9,13 @@ const AppName = "folio212"
 const ConfigDirName = ".folio212"
 
 const ConfigFileName = "config.yaml"
+
+// DefaultProfileName is the profile stored in the top-level trading212_* config settings, so
+// configs written before profiles existed keep working unchanged.
+const DefaultProfileName = "default"
+
+// SnapshotsFileName is the append-only portfolio snapshot log inside the config directory.
+const SnapshotsFileName = "snapshots.jsonl"
+
+// TargetsFileName holds target allocation weights for 'folio212 rebalance' inside the config directory.
+const TargetsFileName = "targets.yaml"
@@ -0,0 +1,299 @@
+// Package jsonschema generates JSON Schema (draft 2020-12) documents from Go types, following the
+// encoding/json rules, and validates decoded JSON against them.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the subset of JSON Schema that Generate produces and Validate understands.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Properties           Properties         `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"-"` // nil = any; see Closed
+	Closed               bool               `json:"-"` // additionalProperties: false
+	Items                *Schema            `json:"items,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// MarshalJSON writes additionalProperties as false for closed objects.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type plain Schema
+	b, err := json.Marshal((*plain)(s))
+	if err != nil {
+		return nil, err
+	}
+	var extra []byte
+	switch {
+	case s.Closed:
+		extra = []byte(`"additionalProperties":false`)
+	case s.AdditionalProperties != nil:
+		ap, err := json.Marshal(s.AdditionalProperties)
+		if err != nil {
+			return nil, err
+		}
+		extra = append([]byte(`"additionalProperties":`), ap...)
+	default:
+		return b, nil
+	}
+	if len(b) > 2 {
+		extra = append([]byte{','}, extra...)
+	}
+	return append(b[:len(b)-1], append(extra, '}')...), nil
+}
+
+// Types is a schema type list, written as a single string when it has one entry.
+type Types []string
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Property is one object property; Properties keeps struct field order.
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+type Properties []Property
+
+func (p Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, prop := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(prop.Name)
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(prop.Schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Lookup returns the schema of the named property.
+func (p Properties) Lookup(name string) (*Schema, bool) {
+	for _, prop := range p {
+		if prop.Name == name {
+			return prop.Schema, true
+		}
+	}
+	return nil, false
+}
+
+var (
+	timeType      = reflect.TypeFor[time.Time]()
+	rawType       = reflect.TypeFor[json.RawMessage]()
+	marshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+// Generate returns the schema of v's type as encoding/json would write it. Named struct types
+// become $defs referenced by $ref; struct objects are closed (additionalProperties: false).
+// Pointers are nullable, as are slices and maps without omitempty (nil encodes as null); fields
+// without omitempty are required.
+func Generate(v any) *Schema {
+	g := &generator{defs: map[string]*Schema{}, names: map[reflect.Type]string{}, reused: map[string]bool{}}
+	root := g.schemaFor(reflect.TypeOf(v))
+	if root.Ref != "" {
+		// Inline the root type so the document itself describes v; a recursive root keeps its
+		// definition for the references inside it.
+		name := strings.TrimPrefix(root.Ref, "#/$defs/")
+		root = g.defs[name]
+		if !g.reused[name] {
+			delete(g.defs, name)
+		} else {
+			copied := *root
+			root = &copied
+		}
+	}
+	root.Schema = Draft
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+type generator struct {
+	defs   map[string]*Schema
+	names  map[reflect.Type]string
+	reused map[string]bool // defs referenced more than once
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case t == rawType:
+		return &Schema{}
+	case t.Kind() != reflect.Pointer && t.Implements(marshalerType):
+		return &Schema{} // custom encoding; shape unknown
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.schemaFor(t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}, Format: "byte"} // base64
+		}
+		return &Schema{Type: Types{"array"}, Items: g.schemaFor(t.Elem())}
+	case reflect.Array:
+		return &Schema{Type: Types{"array"}, Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return g.structRef(t)
+	}
+	return &Schema{} // interfaces and anything else: any value
+}
+
+// structRef registers t in $defs (once) and returns a reference to it.
+func (g *generator) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+	if name, ok := g.names[t]; ok {
+		g.reused[name] = true
+		return &Schema{Ref: "#/$defs/" + name}
+	}
+	name := t.Name()
+	if _, taken := g.defs[name]; taken {
+		name = pkgName(t) + "." + name
+	}
+	g.names[t] = name
+	g.defs[name] = &Schema{} // placeholder for recursive types
+	*g.defs[name] = *g.structSchema(t)
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: Types{"object"}, Closed: true}
+	g.addFields(s, t, 0)
+	return s
+}
+
+// addFields adds t's encoded fields to s, flattening untagged embedded structs; as in
+// encoding/json, a field of the outer struct shadows an embedded one with the same name.
+func (g *generator) addFields(s *Schema, t reflect.Type, depth int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft, depth+1)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		omitEmpty := hasOption(opts, "omitempty") || hasOption(opts, "omitzero")
+
+		var fs *Schema
+		if hasOption(opts, "string") {
+			fs = &Schema{Type: Types{"string"}}
+		} else {
+			fs = g.schemaFor(f.Type)
+		}
+		if !omitEmpty && (f.Type.Kind() == reflect.Slice || f.Type.Kind() == reflect.Map) && f.Type != rawType {
+			fs = nullable(fs)
+		}
+
+		if j := slices.IndexFunc(s.Properties, func(p Property) bool { return p.Name == name }); j >= 0 {
+			if depth == 0 {
+				s.Properties[j].Schema = fs
+			}
+		} else {
+			s.Properties = append(s.Properties, Property{Name: name, Schema: fs})
+		}
+		if !omitEmpty && !slices.Contains(s.Required, name) {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// Projection returns a copy of the object schema s in which every property is optional and may be
+// null, for documents that carry a caller-selected subset of s's properties.
+func Projection(s *Schema) *Schema {
+	p := &Schema{Type: Types{"object"}, Closed: s.Closed, AdditionalProperties: s.AdditionalProperties}
+	for _, prop := range s.Properties {
+		c := *prop.Schema
+		c.Type = slices.Clone(c.Type)
+		p.Properties = append(p.Properties, Property{Name: prop.Name, Schema: nullable(&c)})
+	}
+	return p
+}
+
+// nullable allows null in addition to s.
+func nullable(s *Schema) *Schema {
+	if len(s.Type) > 0 && s.Ref == "" && len(s.AnyOf) == 0 {
+		if !slices.Contains(s.Type, "null") {
+			s.Type = append(s.Type, "null")
+		}
+		return s
+	}
+	if len(s.Type) == 0 && s.Ref == "" && len(s.AnyOf) == 0 {
+		return s // already any value
+	}
+	return &Schema{AnyOf: []*Schema{s, {Type: Types{"null"}}}}
+}
+
+func hasOption(opts, name string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == name {
+			return true
+		}
+	}
+	return false
+}
+
+func pkgName(t reflect.Type) string {
+	path := t.PkgPath()
+	return path[strings.LastIndex(path, "/")+1:]
+}
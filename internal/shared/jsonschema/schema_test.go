@@ -0,0 +1,178 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+type base struct {
+	ID      int64  `json:"id"`
+	Comment string `json:"comment,omitempty"`
+	Shadow  string `json:"shadow"`
+}
+
+type leaf struct {
+	Name string `json:"name"`
+}
+
+type sample struct {
+	base
+	Shadow   int               `json:"shadow"` // shadows base.Shadow
+	Price    float64           `json:"price"`
+	Flag     bool              `json:"flag"`
+	At       time.Time         `json:"at"`
+	Maybe    *float64          `json:"maybe"`
+	Optional *float64          `json:"optional,omitempty"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]int    `json:"labels,omitempty"`
+	Raw      json.RawMessage   `json:"raw,omitempty"`
+	Leaf     leaf              `json:"leaf"`
+	Leaves   []leaf            `json:"leaves"`
+	Next     *leaf             `json:"next"`
+	Quoted   int               `json:"quoted,string"`
+	Data     []byte            `json:"data"`
+	Any      any               `json:"any"`
+	Skipped  string            `json:"-"`
+	Untagged string            //
+	private  string            //nolint:unused // must not appear in the schema
+	Extra    map[string]string `json:"extra"`
+}
+
+func prop(t *testing.T, s *Schema, name string) *Schema {
+	t.Helper()
+	p, ok := s.Properties.Lookup(name)
+	if !ok {
+		t.Fatalf("missing property %q", name)
+	}
+	return p
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(sample{})
+
+	if s.Schema != Draft || !s.Closed || !slices.Equal(s.Type, Types{"object"}) {
+		t.Fatalf("root = %+v", s)
+	}
+
+	var names []string
+	for _, p := range s.Properties {
+		names = append(names, p.Name)
+	}
+	want := []string{"id", "comment", "shadow", "price", "flag", "at", "maybe", "optional", "tags", "labels",
+		"raw", "leaf", "leaves", "next", "quoted", "data", "any", "Untagged", "extra"}
+	if !slices.Equal(names, want) {
+		t.Errorf("properties = %v, want %v", names, want)
+	}
+	wantRequired := []string{"id", "shadow", "price", "flag", "at", "maybe", "tags", "leaf", "leaves", "next",
+		"quoted", "data", "any", "Untagged", "extra"}
+	if !slices.Equal(s.Required, wantRequired) {
+		t.Errorf("required = %v, want %v", s.Required, wantRequired)
+	}
+
+	types := map[string]Types{
+		"id":       {"integer"},
+		"shadow":   {"integer"},
+		"price":    {"number"},
+		"flag":     {"boolean"},
+		"at":       {"string"},
+		"maybe":    {"number", "null"},
+		"optional": {"number", "null"},
+		"tags":     {"array", "null"},
+		"labels":   {"object"},
+		"quoted":   {"string"},
+		"data":     {"string", "null"},
+		"extra":    {"object", "null"},
+	}
+	for name, want := range types {
+		if got := prop(t, s, name).Type; !slices.Equal(got, want) {
+			t.Errorf("%s type = %v, want %v", name, got, want)
+		}
+	}
+	if f := prop(t, s, "at").Format; f != "date-time" {
+		t.Errorf("at format = %q", f)
+	}
+	if raw := prop(t, s, "raw"); len(raw.Type) != 0 || raw.Ref != "" {
+		t.Errorf("raw = %+v, want any value", raw)
+	}
+
+	if ref := prop(t, s, "leaf").Ref; ref != "#/$defs/leaf" {
+		t.Errorf("leaf ref = %q", ref)
+	}
+	if items := prop(t, s, "leaves").Items; items == nil || items.Ref != "#/$defs/leaf" {
+		t.Errorf("leaves items = %+v", items)
+	}
+	next := prop(t, s, "next")
+	if len(next.AnyOf) != 2 || next.AnyOf[0].Ref != "#/$defs/leaf" || !slices.Equal(next.AnyOf[1].Type, Types{"null"}) {
+		t.Errorf("next = %+v, want anyOf [$ref leaf, null]", next)
+	}
+	if _, ok := s.Defs["leaf"]; !ok || len(s.Defs) != 1 {
+		t.Errorf("$defs = %v, want only leaf", s.Defs)
+	}
+}
+
+type node struct {
+	Value    int     `json:"value"`
+	Children []*node `json:"children,omitempty"`
+}
+
+func TestGenerateRecursiveType(t *testing.T) {
+	s := Generate(node{})
+	items := prop(t, s, "children").Items
+	if items == nil || len(items.AnyOf) != 2 || items.AnyOf[0].Ref != "#/$defs/node" {
+		t.Fatalf("children items = %+v", items)
+	}
+	if err := ValidateJSON(s, []byte(`{"value":1,"children":[{"value":2,"children":[{"value":3}]}]}`)); err != nil {
+		t.Error(err)
+	}
+	if err := ValidateJSON(s, []byte(`{"value":1,"children":[{"value":"2"}]}`)); err == nil {
+		t.Error("accepted a string in a nested node")
+	}
+}
+
+func TestSchemaMarshalJSON(t *testing.T) {
+	s := &Schema{
+		Type:       Types{"object"},
+		Closed:     true,
+		Properties: Properties{{Name: "b", Schema: &Schema{Type: Types{"string", "null"}}}, {Name: "a", Schema: &Schema{}}},
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"object","properties":{"b":{"type":["string","null"]},"a":{}},"additionalProperties":false}`
+	if string(b) != want {
+		t.Errorf("got  %s\nwant %s", b, want)
+	}
+
+	m, err := json.Marshal(&Schema{Type: Types{"object"}, AdditionalProperties: &Schema{Type: Types{"integer"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"object","additionalProperties":{"type":"integer"}}`; string(m) != want {
+		t.Errorf("got  %s\nwant %s", m, want)
+	}
+}
+
+func TestProjection(t *testing.T) {
+	s := Generate(sample{})
+	p := Projection(s)
+
+	if len(p.Required) != 0 || !p.Closed {
+		t.Errorf("projection required = %v, closed = %v", p.Required, p.Closed)
+	}
+	if got := prop(t, p, "price").Type; !slices.Equal(got, Types{"number", "null"}) {
+		t.Errorf("price type = %v", got)
+	}
+	if got := prop(t, s, "price").Type; !slices.Equal(got, Types{"number"}) {
+		t.Errorf("original price type changed to %v", got)
+	}
+	p.Defs = s.Defs
+	if err := ValidateJSON(p, []byte(`{"price":null,"leaf":{"name":"x"}}`)); err != nil {
+		t.Error(err)
+	}
+	if err := ValidateJSON(p, []byte(`{"price":1,"unknown":1}`)); err == nil {
+		t.Error("projection accepted an unknown key")
+	}
+}
@@ -0,0 +1,219 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxProblems bounds the problems collected by one validation.
+const maxProblems = 20
+
+// ValidationError lists where a document does not match its schema, as JSON Pointer paths.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "document does not match schema:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// ValidateJSON checks that data is a single JSON document matching s.
+func ValidateJSON(s *Schema, data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if dec.More() {
+		return fmt.Errorf("invalid JSON: more than one document")
+	}
+	return Validate(s, doc)
+}
+
+// Validate checks a decoded document (numbers as json.Number or float64) against s, whose $refs
+// resolve against its own $defs.
+func Validate(s *Schema, doc any) error {
+	v := &validator{root: s}
+	v.check(s, doc, "")
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+type validator struct {
+	root     *Schema
+	problems []string
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	if len(v.problems) == maxProblems {
+		v.problems = append(v.problems, "...")
+	}
+	if len(v.problems) > maxProblems {
+		return
+	}
+	if path == "" {
+		path = "/"
+	}
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+// resolve follows $refs into the root's $defs; ok=false for a reference that does not resolve.
+func (v *validator) resolve(s *Schema) (*Schema, bool) {
+	for s.Ref != "" {
+		def, ok := v.root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if !ok {
+			return s, false
+		}
+		s = def
+	}
+	return s, true
+}
+
+func (v *validator) check(s *Schema, doc any, path string) {
+	s, ok := v.resolve(s)
+	if !ok {
+		v.fail(path, "unresolved $ref %s", s.Ref)
+		return
+	}
+
+	if len(s.AnyOf) > 0 {
+		for _, alt := range s.AnyOf {
+			sub := &validator{root: v.root}
+			sub.check(alt, doc, path)
+			if len(sub.problems) == 0 {
+				return
+			}
+		}
+		v.fail(path, "%s matches none of the allowed shapes", kindOf(doc))
+		return
+	}
+
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return hasType(doc, t) }) {
+		v.fail(path, "expected %s, got %s", strings.Join(s.Type, " or "), kindOf(doc))
+		return
+	}
+	if s.Const != nil && !equalJSON(s.Const, doc) {
+		v.fail(path, "expected %v", s.Const)
+	}
+
+	switch val := doc.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := val[name]; !ok {
+				v.fail(path, "missing required property %q", name)
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			child := path + "/" + escapePointer(k)
+			if ps, ok := s.Properties.Lookup(k); ok {
+				v.check(ps, val[k], child)
+			} else if s.Closed {
+				v.fail(child, "unexpected property")
+			} else if s.AdditionalProperties != nil {
+				v.check(s.AdditionalProperties, val[k], child)
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range val {
+				v.check(s.Items, item, path+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+}
+
+func hasType(doc any, t string) bool {
+	switch t {
+	case "null":
+		return doc == nil
+	case "boolean":
+		_, ok := doc.(bool)
+		return ok
+	case "string":
+		_, ok := doc.(string)
+		return ok
+	case "object":
+		_, ok := doc.(map[string]any)
+		return ok
+	case "array":
+		_, ok := doc.([]any)
+		return ok
+	case "number":
+		switch doc.(type) {
+		case json.Number, float64:
+			return true
+		}
+	case "integer":
+		switch n := doc.(type) {
+		case json.Number:
+			if _, err := n.Int64(); err == nil {
+				return true
+			}
+			f, err := n.Float64()
+			return err == nil && f == math.Trunc(f)
+		case float64:
+			return n == math.Trunc(n)
+		}
+	}
+	return false
+}
+
+func kindOf(doc any) string {
+	switch doc.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case json.Number, float64:
+		return "number"
+	}
+	return fmt.Sprintf("%T", doc)
+}
+
+// equalJSON compares a schema constant with a decoded value as JSON values, so numbers compare
+// by value (2 equals 2.0).
+func equalJSON(want, got any) bool {
+	a, err1 := normalizeJSON(want)
+	b, err2 := normalizeJSON(got)
+	if err1 != nil || err2 != nil {
+		return reflect.DeepEqual(want, got)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// normalizeJSON round-trips v through encoding/json, turning every number into a float64.
+func normalizeJSON(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	err = json.Unmarshal(b, &out)
+	return out, err
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapePointer(s string) string {
+	return pointerEscaper.Replace(s)
+}
@@ -0,0 +1,98 @@
+package jsonschema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	s := &Schema{
+		Type:     Types{"object"},
+		Closed:   true,
+		Required: []string{"version", "items"},
+		Properties: Properties{
+			{Name: "version", Schema: &Schema{Type: Types{"integer"}, Const: 2}},
+			{Name: "items", Schema: &Schema{Type: Types{"array"}, Items: &Schema{AnyOf: []*Schema{
+				{Ref: "#/$defs/full"},
+				{Ref: "#/$defs/partial"},
+			}}}},
+			{Name: "note", Schema: &Schema{Type: Types{"string", "null"}}},
+			{Name: "meta", Schema: &Schema{Type: Types{"object"}, AdditionalProperties: &Schema{Type: Types{"number"}}}},
+			{Name: "alias", Schema: &Schema{Ref: "#/$defs/alias"}},
+			{Name: "broken", Schema: &Schema{Ref: "#/$defs/missing"}},
+		},
+		Defs: map[string]*Schema{
+			"full": {Type: Types{"object"}, Closed: true, Required: []string{"name", "value"}, Properties: Properties{
+				{Name: "name", Schema: &Schema{Type: Types{"string"}}},
+				{Name: "value", Schema: &Schema{Type: Types{"number"}}},
+			}},
+			"partial": {Type: Types{"object"}, Closed: true, Properties: Properties{
+				{Name: "name", Schema: &Schema{Type: Types{"string", "null"}}},
+			}},
+			"alias": {Ref: "#/$defs/partial"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		doc     string
+		problem string // substring of the first problem; "" = valid
+	}{
+		{name: "minimal", doc: `{"version":2,"items":[]}`},
+		{name: "first alternative", doc: `{"version":2,"items":[{"name":"a","value":1.5}]}`},
+		{name: "second alternative", doc: `{"version":2,"items":[{"name":null},{}]}`},
+		{name: "null allowed", doc: `{"version":2,"items":[],"note":null}`},
+		{name: "open map", doc: `{"version":2,"items":[],"meta":{"a":1,"b":2.5}}`},
+		{name: "ref chain", doc: `{"version":2,"items":[],"alias":{"name":"x"}}`},
+		{name: "integer as float", doc: `{"version":2.0,"items":[]}`},
+		{name: "missing required", doc: `{"items":[]}`, problem: `/: missing required property "version"`},
+		{name: "const", doc: `{"version":1,"items":[]}`, problem: "/version: expected 2"},
+		{name: "not integer", doc: `{"version":2.5,"items":[]}`, problem: "/version: expected integer, got number"},
+		{name: "wrong type", doc: `{"version":2,"items":{}}`, problem: "/items: expected array, got object"},
+		{name: "null not allowed", doc: `{"version":2,"items":null}`, problem: "/items: expected array, got null"},
+		{name: "closed object", doc: `{"version":2,"items":[],"extra":1}`, problem: "/extra: unexpected property"},
+		{name: "no alternative", doc: `{"version":2,"items":[{"name":"a","value":"1"}]}`, problem: "/items/0: object matches none"},
+		{name: "map value", doc: `{"version":2,"items":[],"meta":{"a/b":"x"}}`, problem: "/meta/a~1b: expected number, got string"},
+		{name: "unresolved ref", doc: `{"version":2,"items":[],"broken":1}`, problem: "/broken: unresolved $ref #/$defs/missing"},
+		{name: "ref chain mismatch", doc: `{"version":2,"items":[],"alias":{"value":1}}`, problem: "/alias/value: unexpected property"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSON(s, []byte(tt.doc))
+			if tt.problem == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("err = %v, want a ValidationError", err)
+			}
+			if !strings.Contains(verr.Problems[0], tt.problem) {
+				t.Errorf("problems = %q, want %q", verr.Problems, tt.problem)
+			}
+		})
+	}
+}
+
+func TestValidateJSONRejectsInvalidInput(t *testing.T) {
+	for _, doc := range []string{``, `{`, `{} {}`} {
+		if err := ValidateJSON(&Schema{}, []byte(doc)); err == nil || !strings.HasPrefix(err.Error(), "invalid JSON") {
+			t.Errorf("%q: err = %v", doc, err)
+		}
+	}
+}
+
+func TestValidateLimitsProblems(t *testing.T) {
+	doc := "[" + strings.TrimSuffix(strings.Repeat(`"x",`, 50), ",") + "]"
+	err := ValidateJSON(&Schema{Type: Types{"array"}, Items: &Schema{Type: Types{"integer"}}}, []byte(doc))
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("err = %v", err)
+	}
+	if len(verr.Problems) != maxProblems+1 || verr.Problems[maxProblems] != "..." {
+		t.Errorf("got %d problems, last %q", len(verr.Problems), verr.Problems[len(verr.Problems)-1])
+	}
+}
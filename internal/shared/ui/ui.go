@@ -3,6 +3,10 @@ package ui
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
 )
 
 func ExitWithError(msg string, err error) {
@@ -13,3 +17,37 @@ func ExitWithError(msg string, err error) {
 	}
 	os.Exit(1)
 }
+
+// IsTerminal reports whether f is an interactive terminal (not a pipe or file).
+func IsTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ColorEnabled reports whether styled output should be written to f: it must be a terminal,
+// NO_COLOR (https://no-color.org) must be unset or empty and TERM must not be "dumb".
+func ColorEnabled(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal(f)
+}
+
+// TerminalWidth returns $COLUMNS when set, else the width of f's terminal, else fallback.
+func TerminalWidth(f *os.File, fallback int) int {
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("COLUMNS"))); err == nil && n > 0 {
+		return n
+	}
+	if f != nil && IsTerminal(f) {
+		if w, _, err := term.GetSize(f.Fd()); err == nil && w > 0 {
+			return w
+		}
+	}
+	return fallback
+}
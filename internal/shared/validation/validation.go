@@ -11,3 +11,17 @@ func ValidateNonEmpty(fieldName, value string) error {
 	}
 	return nil
 }
+
+// ValidateProfileName accepts lowercase letters, digits, '-' and '_' (names end up in keyring keys
+// and environment variable names).
+func ValidateProfileName(name string) error {
+	if name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return fmt.Errorf("invalid profile name %q (use lowercase letters, digits, '-' or '_')", name)
+		}
+	}
+	return nil
+}